@@ -0,0 +1,213 @@
+/*
+ * anchorlayout.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"github.com/gopxl/pixel"
+)
+
+// Struct for anchor layouts, containers that place their
+// children relative to layout edges, corners or center.
+// If layout size is not specified, then layout takes size
+// of the draw target bounds(e.g. window bounds), so children
+// are repositioned after each window resize.
+type AnchorLayout struct {
+	size     pixel.Vec
+	drawArea pixel.Rect // updated on each draw
+	children []*anchorChild
+}
+
+// Struct for anchor layout child element.
+type anchorChild struct {
+	widget  Widget
+	anchor  Anchor
+	offset  pixel.Vec
+	stretch Stretch
+}
+
+// Interface for UI elements with adjustable size.
+type resizer interface {
+	SetSize(s pixel.Vec)
+}
+
+// Interface for draw targets with bounds, like
+// window or canvas.
+type boundsTarget interface {
+	Bounds() pixel.Rect
+}
+
+// NewAnchorLayout creates new anchor layout with specified
+// parameters.
+func NewAnchorLayout(params Params) *AnchorLayout {
+	al := new(AnchorLayout)
+	al.size = params.SizeRaw
+	return al
+}
+
+// Draw draws all layout children.
+func (al *AnchorLayout) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Calculating draw area.
+	size := al.size
+	if bt, ok := t.(boundsTarget); ok && size == pixel.ZV {
+		size = bt.Bounds().Size()
+	}
+	al.drawArea = MatrixToDrawArea(matrix, size)
+	// Children.
+	for _, c := range al.children {
+		move := al.childMove(c)
+		c.widget.Draw(t, matrix.Moved(move))
+	}
+}
+
+// Update updates all layout children.
+func (al *AnchorLayout) Update(win Input) {
+	for _, c := range al.children {
+		c.widget.Update(win)
+	}
+}
+
+// Add adds specified element to the layout, with specified
+// anchor and offset(for 1080p) from the anchor point.
+// Offset for edges and corners is directed to the inside of
+// layout, offset for center is directed up and right.
+func (al *AnchorLayout) Add(w Widget, anchor Anchor, offset pixel.Vec) {
+	c := anchorChild{
+		widget:  w,
+		anchor:  anchor,
+		offset:  offset,
+		stretch: StretchNone,
+	}
+	al.children = append(al.children, &c)
+}
+
+// Remove removes specified element from the layout.
+func (al *AnchorLayout) Remove(w Widget) {
+	for i, c := range al.children {
+		if c.widget == w {
+			al.children = append(al.children[:i], al.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the layout.
+func (al *AnchorLayout) Clear() {
+	al.children = nil
+}
+
+// Children returns all layout elements.
+func (al *AnchorLayout) Children() []Widget {
+	children := make([]Widget, len(al.children))
+	for i, c := range al.children {
+		children[i] = c.widget
+	}
+	return children
+}
+
+// ApplyTheme applies specified theme to all layout
+// elements.
+func (al *AnchorLayout) ApplyTheme(t *Theme) {
+	applyChildrenTheme(al.Children(), t)
+}
+
+// SetStretch sets stretch for specified element.
+// Stretched element fills whole layout width and/or height,
+// with offset used as margin on both sides.
+// Stretch works only for elements with adjustable size,
+// like textboxes, text edits or panels.
+func (al *AnchorLayout) SetStretch(w Widget, stretch Stretch) {
+	for _, c := range al.children {
+		if c.widget == w {
+			c.stretch = stretch
+			return
+		}
+	}
+}
+
+// SetSize sets layout size.
+// Zero size makes layout to use bounds of the
+// draw target.
+func (al *AnchorLayout) SetSize(s pixel.Vec) {
+	al.size = s
+}
+
+// Size returns layout size.
+func (al *AnchorLayout) Size() pixel.Vec {
+	if al.size == pixel.ZV {
+		return al.drawArea.Size()
+	}
+	return al.size
+}
+
+// DrawArea returns current layout draw area.
+func (al *AnchorLayout) DrawArea() pixel.Rect {
+	return al.drawArea
+}
+
+// childMove returns move vector from the layout center to
+// the draw position(center) of specified child.
+// Stretched child is resized to fit the layout before
+// calculating its position.
+func (al *AnchorLayout) childMove(c *anchorChild) pixel.Vec {
+	area := al.DrawArea()
+	offset := ConvVec(c.offset)
+	r, resizable := c.widget.(resizer)
+	stretchH := resizable && (c.stretch == StretchHorizontal || c.stretch == StretchBoth)
+	stretchV := resizable && (c.stretch == StretchVertical || c.stretch == StretchBoth)
+	if stretchH || stretchV {
+		size := c.widget.Size()
+		if stretchH {
+			size.X = area.W() - offset.X*2
+		}
+		if stretchV {
+			size.Y = area.H() - offset.Y*2
+		}
+		r.SetSize(size)
+	}
+	size := c.widget.Size()
+	var move pixel.Vec
+	switch c.anchor {
+	case AnchorTopLeft, AnchorLeft, AnchorBottomLeft:
+		move.X = -area.W()/2 + offset.X + size.X/2
+	case AnchorTopRight, AnchorRight, AnchorBottomRight:
+		move.X = area.W()/2 - offset.X - size.X/2
+	default:
+		move.X = offset.X
+	}
+	switch c.anchor {
+	case AnchorTopLeft, AnchorTop, AnchorTopRight:
+		move.Y = area.H()/2 - offset.Y - size.Y/2
+	case AnchorBottomLeft, AnchorBottom, AnchorBottomRight:
+		move.Y = -area.H()/2 + offset.Y + size.Y/2
+	default:
+		move.Y = offset.Y
+	}
+	if stretchH {
+		move.X = 0
+	}
+	if stretchV {
+		move.Y = 0
+	}
+	return move
+}
@@ -67,7 +67,7 @@ func (anim *Animation) DrawColorMask(t pixel.Target, matrix pixel.Matrix, mask c
 }
 
 // Update updates animation.
-func (anim *Animation) Update(win *Window) {
+func (anim *Animation) Update(win Input) {
 	if anim.Finished() {
 		return
 	}
@@ -87,6 +87,11 @@ func (anim *Animation) DrawArea() pixel.Rect {
 	return anim.drawArea
 }
 
+// Size returns size of current animation frame.
+func (anim *Animation) Size() pixel.Vec {
+	return anim.frames[anim.drawFrameID].Frame().Size()
+}
+
 // SetCurrentFrameID sets frame with specified ID as
 // current draw frame of animation. If specified index is
 // bigger than maximal frame index then first index is set,
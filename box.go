@@ -0,0 +1,254 @@
+/*
+ * box.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for box layouts, containers that place their
+// children one after another in a single row(horizontal
+// box) or column(vertical box).
+// Positions of children are calculated on each draw, so
+// layout is always up to date with current children sizes.
+type Box struct {
+	bgSpr      *pixel.Sprite
+	size       pixel.Vec
+	color      color.Color
+	drawArea   pixel.Rect // updated on each draw
+	horizontal bool
+	padding    float64
+	spacing    float64
+	align      Align
+	children   []Widget
+}
+
+// NewHBox creates new horizontal box layout with
+// specified parameters.
+// If raw size is not specified then box size is
+// calculated from sizes of its children.
+func NewHBox(params Params) *Box {
+	b := newBox(params)
+	b.horizontal = true
+	return b
+}
+
+// NewVBox creates new vertical box layout with
+// specified parameters.
+// If raw size is not specified then box size is
+// calculated from sizes of its children.
+func NewVBox(params Params) *Box {
+	return newBox(params)
+}
+
+// newBox creates new box layout with specified
+// parameters.
+func newBox(params Params) *Box {
+	b := new(Box)
+	b.size = params.SizeRaw
+	b.color = params.MainColor
+	if b.color == nil {
+		b.color = theme.Panel.MainColor
+	}
+	b.bgSpr = params.Background
+	if b.bgSpr == nil {
+		b.bgSpr = theme.Panel.Background
+	}
+	b.align = AlignCenter
+	return b
+}
+
+// Draw draws box background and all box children.
+func (b *Box) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Calculating draw area.
+	b.drawArea = MatrixToDrawArea(matrix, b.Size())
+	// Background.
+	if b.bgSpr != nil {
+		b.bgSpr.Draw(t, matrix)
+	} else if b.color != nil {
+		DrawRect(t, b.DrawArea(), b.color)
+	}
+	// Children.
+	for i, move := range b.childrenMoves() {
+		b.children[i].Draw(t, matrix.Moved(move))
+	}
+}
+
+// Update updates all box children.
+func (b *Box) Update(win Input) {
+	for _, c := range b.children {
+		c.Update(win)
+	}
+}
+
+// Add adds specified elements at the end of the box.
+func (b *Box) Add(w ...Widget) {
+	b.children = append(b.children, w...)
+}
+
+// Remove removes specified element from the box.
+func (b *Box) Remove(w Widget) {
+	for i, c := range b.children {
+		if c == w {
+			b.children = append(b.children[:i], b.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the box.
+func (b *Box) Clear() {
+	b.children = nil
+}
+
+// Children returns all box elements.
+func (b *Box) Children() []Widget {
+	return b.children
+}
+
+// SetPadding sets specified value(for 1080p) as
+// space between box edges and its content.
+func (b *Box) SetPadding(padding float64) {
+	b.padding = padding
+}
+
+// SetSpacing sets specified value(for 1080p) as
+// space between box children.
+func (b *Box) SetSpacing(spacing float64) {
+	b.spacing = spacing
+}
+
+// SetAlign sets align of box children.
+// Horizontal box supports top, center and bottom
+// aligns, vertical box supports left, center and
+// right aligns.
+func (b *Box) SetAlign(a Align) {
+	b.align = a
+}
+
+// ApplyTheme sets color and background from panel style
+// of specified theme.
+// Theme is also applied to all box elements.
+func (b *Box) ApplyTheme(t *Theme) {
+	b.color = t.Panel.MainColor
+	b.bgSpr = t.Panel.Background
+	applyChildrenTheme(b.Children(), t)
+}
+
+// SetBackground sets specified sprite as box
+// background, also removes background color.
+func (b *Box) SetBackground(s *pixel.Sprite) {
+	b.bgSpr = s
+	b.color = nil
+}
+
+// SetColor sets specified color as box
+// background color.
+func (b *Box) SetColor(c color.Color) {
+	b.color = c
+}
+
+// SetSize sets box size.
+func (b *Box) SetSize(s pixel.Vec) {
+	b.size = s
+}
+
+// Size returns box size.
+func (b *Box) Size() pixel.Vec {
+	if b.bgSpr != nil {
+		return b.bgSpr.Frame().Size()
+	}
+	if b.size != pixel.ZV {
+		return b.size
+	}
+	return b.contentSize()
+}
+
+// DrawArea returns current box draw area.
+func (b *Box) DrawArea() pixel.Rect {
+	return b.drawArea
+}
+
+// contentSize returns size of box children with
+// padding and spacing.
+func (b *Box) contentSize() pixel.Vec {
+	padding := ConvSize(b.padding)
+	spacing := ConvSize(b.spacing)
+	var size pixel.Vec
+	for i, c := range b.children {
+		childSize := c.Size()
+		if b.horizontal {
+			size.X += childSize.X
+			size.Y = math.Max(size.Y, childSize.Y)
+		} else {
+			size.X = math.Max(size.X, childSize.X)
+			size.Y += childSize.Y
+		}
+		if i == 0 {
+			continue
+		}
+		if b.horizontal {
+			size.X += spacing
+		} else {
+			size.Y += spacing
+		}
+	}
+	return size.Add(pixel.V(padding*2, padding*2))
+}
+
+// childrenMoves returns move vectors from the box center
+// to draw positions(center) of all box children.
+func (b *Box) childrenMoves() []pixel.Vec {
+	size := b.Size()
+	padding := ConvSize(b.padding)
+	spacing := ConvSize(b.spacing)
+	moves := make([]pixel.Vec, len(b.children))
+	pos := pixel.V(-size.X/2+padding, size.Y/2-padding)
+	for i, c := range b.children {
+		childSize := c.Size()
+		if b.horizontal {
+			moves[i].X = pos.X + childSize.X/2
+			pos.X += childSize.X + spacing
+			switch b.align {
+			case AlignTop:
+				moves[i].Y = size.Y/2 - padding - childSize.Y/2
+			case AlignBottom:
+				moves[i].Y = -size.Y/2 + padding + childSize.Y/2
+			}
+			continue
+		}
+		moves[i].Y = pos.Y - childSize.Y/2
+		pos.Y -= childSize.Y + spacing
+		switch b.align {
+		case AlignLeft:
+			moves[i].X = -size.X/2 + padding + childSize.X/2
+		case AlignRight:
+			moves[i].X = size.X/2 - padding - childSize.X/2
+		}
+	}
+	return moves
+}
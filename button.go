@@ -26,19 +26,12 @@ package mtk
 import (
 	"image/color"
 
-	"golang.org/x/image/colornames"
-
 	"github.com/gopxl/beep"
 
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
 )
 
-var (
-	buttonPushColor  = colornames.Grey
-	buttonHoverColor = colornames.Crimson
-)
-
 // Button struct for UI button.
 type Button struct {
 	bgSpr      *pixel.Sprite
@@ -49,6 +42,8 @@ type Button struct {
 	color      color.Color
 	colorPush  color.Color
 	colorHover color.Color
+	colorDis   color.Color
+	colorFocus color.Color
 	pressed    bool
 	focused    bool
 	hovered    bool
@@ -65,20 +60,35 @@ func NewButton(params Params) *Button {
 	b.shape = params.Shape
 	b.size = params.Size.ButtonSize(b.shape)
 	b.color = params.MainColor
-	b.colorPush = buttonPushColor
-	b.colorHover = buttonHoverColor
+	if b.color == nil {
+		b.color = theme.Button.MainColor
+	}
+	b.colorPush = theme.Button.PressedColor
+	b.colorHover = theme.Button.HoverColor
+	b.colorDis = theme.Button.DisabledColor
+	b.colorFocus = theme.Button.FocusedColor
 	// Background.
 	b.bgSpr = params.Background
+	if b.bgSpr == nil {
+		b.bgSpr = theme.Button.Background
+	}
 	// Label.
 	labelParams := Params{
-		SizeRaw:  pixel.V(b.Size().X, 0),
-		FontSize: params.FontSize,
+		SizeRaw:     pixel.V(b.Size().X, 0),
+		FontSize:    params.FontSize,
+		Font:        params.Font,
+		Overflow:    params.Overflow,
+		TextEffects: params.TextEffects,
+	}
+	if len(labelParams.Font) < 1 {
+		labelParams.Font = theme.Button.Font
 	}
 	b.label = NewText(labelParams)
 	// Info window.
 	infoParams := Params{
-		FontSize:  SizeSmall,
-		MainColor: pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		FontSize:  theme.InfoWindow.FontSize,
+		Font:      theme.InfoWindow.Font,
+		MainColor: theme.InfoWindow.SecColor,
 	}
 	b.info = NewInfoWindow(infoParams)
 	// Global click sound.
@@ -92,10 +102,15 @@ func (b *Button) Draw(t pixel.Target, matrix pixel.Matrix) {
 	b.drawArea = MatrixToDrawArea(matrix, b.Size())
 	// Drawing background.
 	bgColor := b.color
-	if b.pressed || b.Disabled() {
+	switch {
+	case b.Disabled():
+		bgColor = b.colorDis
+	case b.pressed:
 		bgColor = b.colorPush
-	} else if b.hovered {
+	case b.hovered:
 		bgColor = b.colorHover
+	case b.Focused() && b.colorFocus != nil:
+		bgColor = b.colorFocus
 	}
 	if b.bgSpr != nil {
 		if bgColor == nil {
@@ -118,7 +133,8 @@ func (b *Button) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates button.
-func (b *Button) Update(win *Window) {
+func (b *Button) Update(win Input) {
+	b.label.Update(win)
 	if b.Disabled() {
 		return
 	}
@@ -130,12 +146,7 @@ func (b *Button) Update(win *Window) {
 	}
 	if win.JustReleased(pixelgl.MouseButtonLeft) {
 		if b.pressed && b.DrawArea().Contains(win.MousePosition()) {
-			if b.onClick != nil {
-				b.onClick(b)
-			}
-			if audio != nil && b.clickSound != nil {
-				audio.Play(b.clickSound)
-			}
+			b.click()
 		}
 		b.pressed = false
 	}
@@ -148,12 +159,35 @@ func (b *Button) Update(win *Window) {
 	}
 	// On-focus events.
 	if b.Focused() {
-		if win.JustPressed(pixelgl.KeyEnter) {
+		if win.JustPressed(pixelgl.KeyEnter) && b.onClick != nil {
 			b.onClick(b)
 		}
 	}
 }
 
+// HandleAction handles specified user action.
+// Accept action clicks the button.
+func (b *Button) HandleAction(a Action) bool {
+	if b.Disabled() || a != ActionAccept {
+		return false
+	}
+	b.click()
+	return true
+}
+
+// ApplyTheme sets colors and background from button
+// style of specified theme.
+func (b *Button) ApplyTheme(t *Theme) {
+	b.color = t.Button.MainColor
+	b.colorPush = t.Button.PressedColor
+	b.colorHover = t.Button.HoverColor
+	b.colorDis = t.Button.DisabledColor
+	b.colorFocus = t.Button.FocusedColor
+	b.bgSpr = t.Button.Background
+	b.label.ApplyTheme(t)
+	b.info.applyElementTheme(t)
+}
+
 // SetBackground sets specified sprite as button
 // background, also removes background color.
 func (b *Button) SetBackground(s *pixel.Sprite) {
@@ -172,12 +206,31 @@ func (b *Button) SetLabel(t string) {
 	b.label.SetText(t)
 }
 
+// SetLabelEffects sets specified effects for button
+// label.
+func (b *Button) SetLabelEffects(effects TextEffects) {
+	b.label.SetEffects(effects)
+}
+
 // SetInfo sets specified text as content of
 // button info window.
 func (b *Button) SetInfo(t string) {
 	b.info.SetText(t)
 }
 
+// SetLabelKey sets text for specified translation key,
+// formatted with specified arguments, as button label.
+func (b *Button) SetLabelKey(key string, args ...interface{}) {
+	b.label.SetTextKey(key, args...)
+}
+
+// SetInfoKey sets text for specified translation key,
+// formatted with specified arguments, as content of
+// button info window.
+func (b *Button) SetInfoKey(key string, args ...interface{}) {
+	b.info.SetTextKey(key, args...)
+}
+
 // Focus sets/removes focus from button
 func (b *Button) Focus(focus bool) {
 	b.focused = focus
@@ -222,3 +275,14 @@ func (b *Button) Size() pixel.Vec {
 	}
 	return b.bgSpr.Frame().Size()
 }
+
+// click triggers on-click function and plays
+// click sound.
+func (b *Button) click() {
+	if b.onClick != nil {
+		b.onClick(b)
+	}
+	if audio != nil && b.clickSound != nil {
+		audio.Play(b.clickSound)
+	}
+}
@@ -0,0 +1,472 @@
+/*
+ * catalog.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Struct for translation catalog with texts in single
+// language.
+// Each text is stored under translation key, with one
+// or more plural forms.
+type Catalog struct {
+	language string
+	texts    map[string][]string
+	plural   func(n int) int
+}
+
+// NewCatalog creates new empty translation catalog for
+// specified language(e.g. 'en' or 'pl_PL').
+// Plural function is selected based on specified language.
+func NewCatalog(lang string) *Catalog {
+	c := new(Catalog)
+	c.language = lang
+	c.texts = make(map[string][]string)
+	c.plural = pluralFunc(lang)
+	return c
+}
+
+// LoadCatalog loads translation catalog for specified language
+// from file with specified path.
+// Supported formats are gettext PO files(.po) and key=value
+// files(any other extension).
+// In key=value files plural forms are specified with form
+// index after the key, e.g. 'key[1]=value', '#' starts a comment
+// line and '\n' in value is replaced with a new line.
+func LoadCatalog(lang, path string) (*Catalog, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file: %v", err)
+	}
+	c := NewCatalog(lang)
+	if strings.ToLower(filepath.Ext(path)) == ".po" {
+		err = c.parsePO(file)
+	} else {
+		err = c.parseKeyValue(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse catalog: %v", err)
+	}
+	return c, nil
+}
+
+// AddCatalog adds specified catalog to translation catalogs.
+// If catalog for the same language was already added, then
+// texts from specified catalog are added to it.
+func AddCatalog(c *Catalog) {
+	added := catalogs[c.language]
+	if added == nil {
+		catalogs[c.language] = c
+		return
+	}
+	for k, forms := range c.texts {
+		added.Set(k, forms...)
+	}
+}
+
+// SetLanguage sets language of translation catalog used
+// to translate UI texts.
+// All UI texts set with translation keys are updated with
+// texts in the new language.
+// Texts missing in the catalog are taken from the English
+// catalog.
+func SetLanguage(lang string) {
+	language = lang
+	langRevision++
+}
+
+// SetFuzzyLoading sets whether translations marked as fuzzy
+// in PO files are loaded by LoadCatalog.
+// Fuzzy translations are skipped by default, like in
+// gettext tools.
+func SetFuzzyLoading(load bool) {
+	loadFuzzy = load
+}
+
+// Language returns current language of UI texts.
+func Language() string {
+	return language
+}
+
+// Tr returns text for specified translation key in current
+// language, formatted with specified arguments.
+// Arguments are formatted like in fmt.Sprintf.
+// If there is no text for key, then the key itself is
+// returned, without formatting.
+func Tr(key string, args ...interface{}) string {
+	text, ok := catalogText(key)
+	if !ok {
+		return key
+	}
+	return formatText(text, args)
+}
+
+// TrC returns text for specified translation key in specified
+// context, in current language, formatted with specified
+// arguments.
+// Texts with context are loaded from PO entries with msgctxt.
+// If there is no text for key in context, then the key itself
+// is returned, without formatting.
+func TrC(context, key string, args ...interface{}) string {
+	text, ok := catalogText(contextKey(context, key))
+	if !ok {
+		return key
+	}
+	return formatText(text, args)
+}
+
+// TrN returns plural form of text for specified translation
+// key in current language, selected for specified number and
+// formatted with specified arguments.
+// If there is no text for key, then the key itself is
+// returned, without formatting.
+func TrN(key string, n int, args ...interface{}) string {
+	for _, lang := range []string{language, fallbackLanguage} {
+		if c := catalogs[lang]; c != nil {
+			if t, ok := c.PluralText(key, n); ok {
+				return formatText(t, args)
+			}
+		}
+	}
+	return key
+}
+
+// Struct for translated text, with translation key
+// and formatting arguments.
+type translation struct {
+	key    string
+	n      int
+	plural bool
+	args   []interface{}
+}
+
+// text returns translated text in current language.
+func (t *translation) text() string {
+	if t.plural {
+		return TrN(t.key, t.n, t.args...)
+	}
+	return Tr(t.key, t.args...)
+}
+
+// Language returns catalog language.
+func (c *Catalog) Language() string {
+	return c.language
+}
+
+// Set sets specified plural forms as text for specified
+// translation key.
+func (c *Catalog) Set(key string, forms ...string) {
+	c.texts[key] = forms
+}
+
+// Text returns text for specified translation key.
+// Returns false if there is no text for specified key.
+func (c *Catalog) Text(key string) (string, bool) {
+	forms := c.texts[key]
+	if len(forms) < 1 {
+		return "", false
+	}
+	return forms[0], true
+}
+
+// PluralText returns plural form of text for specified
+// translation key selected for specified number.
+// Returns false if there is no text for specified key.
+func (c *Catalog) PluralText(key string, n int) (string, bool) {
+	forms := c.texts[key]
+	if len(forms) < 1 {
+		return "", false
+	}
+	form := c.plural(n)
+	if form < 0 || form >= len(forms) {
+		form = len(forms) - 1
+	}
+	return forms[form], true
+}
+
+// SetPluralFunc sets specified function as function that
+// returns index of plural form for specified number.
+func (c *Catalog) SetPluralFunc(f func(n int) int) {
+	c.plural = f
+}
+
+// parseKeyValue parses specified key=value data and adds
+// all texts to the catalog.
+func (c *Catalog) parseKeyValue(data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) < 1 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return fmt.Errorf("line %d: missing '='", line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.ReplaceAll(strings.TrimSpace(value), "\\n", "\n")
+		key, form, err := splitFormIndex(key)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		c.setForm(key, form, value)
+	}
+	return scanner.Err()
+}
+
+// parsePO parses specified gettext PO data and adds all
+// translated texts to the catalog.
+// Plural forms are selected with catalog plural function,
+// Plural-Forms header is ignored.
+// Texts from entries with msgctxt are set under context keys,
+// and can be retrieved with TrC.
+// Entries marked as fuzzy are skipped, unless fuzzy loading
+// is enabled with SetFuzzyLoading.
+func (c *Catalog) parsePO(data []byte) error {
+	var (
+		ctxt  string
+		id    string
+		forms []string
+		fuzzy bool
+		field *string // field for continuation lines
+	)
+	addEntry := func() {
+		if fuzzy && !loadFuzzy {
+			forms = nil
+		}
+		if len(id) > 0 && len(forms) > 0 && len(forms[0]) > 0 {
+			key := id
+			if len(ctxt) > 0 {
+				key = contextKey(ctxt, id)
+			}
+			c.Set(key, forms...)
+		}
+		ctxt = ""
+		id = ""
+		forms = nil
+		fuzzy = false
+		field = nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) < 1 {
+			addEntry()
+			continue
+		}
+		if strings.HasPrefix(text, "#") {
+			if len(forms) > 0 {
+				addEntry()
+			}
+			if flags, ok := strings.CutPrefix(text, "#,"); ok {
+				for _, f := range strings.Split(flags, ",") {
+					fuzzy = fuzzy || strings.TrimSpace(f) == "fuzzy"
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(text, "\"") {
+			if field == nil {
+				continue
+			}
+			value, err := strconv.Unquote(text)
+			if err != nil {
+				return fmt.Errorf("line %d: %v", line, err)
+			}
+			*field += value
+			continue
+		}
+		keyword, quoted, ok := strings.Cut(text, " ")
+		if !ok {
+			return fmt.Errorf("line %d: invalid entry", line)
+		}
+		value, err := strconv.Unquote(strings.TrimSpace(quoted))
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		switch {
+		case keyword == "msgid":
+			if len(forms) > 0 {
+				addEntry()
+			}
+			id = value
+			field = &id
+		case keyword == "msgctxt":
+			if len(forms) > 0 {
+				addEntry()
+			}
+			ctxt = value
+			field = &ctxt
+		case keyword == "msgid_plural":
+			field = nil
+		case strings.HasPrefix(keyword, "msgstr"):
+			_, form, err := splitFormIndex(keyword)
+			if err != nil {
+				return fmt.Errorf("line %d: %v", line, err)
+			}
+			for len(forms) <= form {
+				forms = append(forms, "")
+			}
+			forms[form] = value
+			field = &forms[form]
+		default:
+			return fmt.Errorf("line %d: unknown keyword: %s", line, keyword)
+		}
+	}
+	addEntry()
+	return scanner.Err()
+}
+
+// setForm sets specified text as plural form with
+// specified index for specified key.
+func (c *Catalog) setForm(key string, form int, text string) {
+	forms := c.texts[key]
+	for len(forms) <= form {
+		forms = append(forms, "")
+	}
+	forms[form] = text
+	c.texts[key] = forms
+}
+
+// splitFormIndex splits specified key with plural form
+// index(e.g. 'key[1]') to key and form index.
+// Form index is 0 if key has no index.
+func splitFormIndex(key string) (string, int, error) {
+	start := strings.Index(key, "[")
+	if start < 0 || !strings.HasSuffix(key, "]") {
+		return key, 0, nil
+	}
+	form, err := strconv.Atoi(key[start+1 : len(key)-1])
+	if err != nil || form < 0 {
+		return key, 0, fmt.Errorf("invalid plural form index: %s", key)
+	}
+	return key[:start], form, nil
+}
+
+// catalogText returns text for specified translation key
+// from catalog for current language, or from the fallback
+// catalog.
+// Returns false if there is no text for specified key.
+func catalogText(key string) (string, bool) {
+	for _, lang := range []string{language, fallbackLanguage} {
+		if c := catalogs[lang]; c != nil {
+			if t, ok := c.Text(key); ok {
+				return t, true
+			}
+		}
+	}
+	return "", false
+}
+
+// contextKey returns translation key for specified key
+// in specified context, in the same form as gettext
+// uses in compiled catalogs.
+func contextKey(context, key string) string {
+	return context + "\x04" + key
+}
+
+// formatText formats specified text with specified
+// arguments.
+func formatText(text string, args []interface{}) string {
+	if len(args) < 1 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// defaultCatalog returns English catalog with texts
+// used by UI elements.
+func defaultCatalog() *Catalog {
+	c := NewCatalog(fallbackLanguage)
+	c.Set("accept", "Accept")
+	c.Set("cancel", "Cancel")
+	return c
+}
+
+// pluralFunc returns plural function for specified
+// language.
+func pluralFunc(lang string) func(n int) int {
+	lang, _, _ = strings.Cut(strings.ToLower(lang), "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	switch lang {
+	case "ja", "ko", "zh", "vi", "th", "id":
+		return func(n int) int {
+			return 0
+		}
+	case "fr", "pt":
+		return func(n int) int {
+			if n > 1 {
+				return 1
+			}
+			return 0
+		}
+	case "pl":
+		return func(n int) int {
+			switch {
+			case n == 1:
+				return 0
+			case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+				return 1
+			default:
+				return 2
+			}
+		}
+	case "ru", "uk", "be", "sr", "hr", "bs":
+		return func(n int) int {
+			switch {
+			case n%10 == 1 && n%100 != 11:
+				return 0
+			case n%10 >= 2 && n%10 <= 4 && (n%100 < 10 || n%100 >= 20):
+				return 1
+			default:
+				return 2
+			}
+		}
+	case "cs", "sk":
+		return func(n int) int {
+			switch {
+			case n == 1:
+				return 0
+			case n >= 2 && n <= 4:
+				return 1
+			default:
+				return 2
+			}
+		}
+	default:
+		return func(n int) int {
+			if n == 1 {
+				return 0
+			}
+			return 1
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+ * catalog_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"testing"
+)
+
+// PO data for catalog tests.
+const testPO = `msgid ""
+msgstr ""
+"Language: pl\n"
+
+msgid "open"
+msgstr "Otwórz"
+
+msgctxt "menu"
+msgid "open"
+msgstr "Otwórz menu"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d plik"
+msgstr[1] "%d pliki"
+msgstr[2] "%d plików"
+
+msgid "long"
+msgstr ""
+"first "
+"second"
+#, fuzzy
+msgid "draft"
+msgstr "Szkic"
+
+msgid "untranslated"
+msgstr ""
+`
+
+// setTestCatalogs sets specified catalogs as the only
+// translation catalogs, besides the default English one,
+// and specified language as current language, until the
+// end of specified test.
+func setTestCatalogs(t *testing.T, lang string, cs ...*Catalog) {
+	prevCatalogs, prevLang, prevFuzzy := catalogs, language, loadFuzzy
+	t.Cleanup(func() {
+		catalogs = prevCatalogs
+		language = prevLang
+		loadFuzzy = prevFuzzy
+	})
+	catalogs = map[string]*Catalog{fallbackLanguage: defaultCatalog()}
+	for _, c := range cs {
+		AddCatalog(c)
+	}
+	SetLanguage(lang)
+}
+
+// TestTr tests translation of texts.
+func TestTr(t *testing.T) {
+	c := NewCatalog("pl")
+	c.Set("greeting", "Cześć %s")
+	setTestCatalogs(t, "pl", c)
+	if text := Tr("greeting", "Ala"); text != "Cześć Ala" {
+		t.Errorf("translated text: '%s', expected: 'Cześć Ala'", text)
+	}
+	if text := Tr("accept"); text != "Accept" {
+		t.Errorf("fallback text: '%s', expected: 'Accept'", text)
+	}
+	if text := Tr("missing %d", 5); text != "missing %d" {
+		t.Errorf("missing text: '%s', expected: 'missing %%d'", text)
+	}
+}
+
+// TestTrN tests translation of plural texts.
+func TestTrN(t *testing.T) {
+	c := NewCatalog("pl")
+	c.Set("%d file", "%d plik", "%d pliki", "%d plików")
+	setTestCatalogs(t, "pl", c)
+	tests := []struct {
+		n    int
+		text string
+	}{
+		{1, "1 plik"},
+		{3, "3 pliki"},
+		{5, "5 plików"},
+		{12, "12 plików"},
+		{22, "22 pliki"},
+	}
+	for _, test := range tests {
+		if text := TrN("%d file", test.n, test.n); text != test.text {
+			t.Errorf("plural text for %d: '%s', expected: '%s'", test.n, text, test.text)
+		}
+	}
+	if text := TrN("%d missing", 2, 2); text != "%d missing" {
+		t.Errorf("missing plural text: '%s', expected: '%%d missing'", text)
+	}
+}
+
+// TestParsePO tests parsing of PO data.
+func TestParsePO(t *testing.T) {
+	setTestCatalogs(t, "pl")
+	c := NewCatalog("pl")
+	if err := c.parsePO([]byte(testPO)); err != nil {
+		t.Fatalf("unable to parse PO data: %v", err)
+	}
+	texts := map[string]string{
+		"open":                     "Otwórz",
+		contextKey("menu", "open"): "Otwórz menu",
+		"long":                     "first second",
+	}
+	for key, expected := range texts {
+		if text, ok := c.Text(key); !ok || text != expected {
+			t.Errorf("text for '%s': '%s', expected: '%s'", key, text, expected)
+		}
+	}
+	if text, _ := c.PluralText("%d file", 5); text != "%d plików" {
+		t.Errorf("plural text: '%s', expected: '%%d plików'", text)
+	}
+	for _, key := range []string{"", "draft", "untranslated"} {
+		if text, ok := c.Text(key); ok {
+			t.Errorf("text for '%s': '%s', expected no text", key, text)
+		}
+	}
+	AddCatalog(c)
+	if text := TrC("menu", "open"); text != "Otwórz menu" {
+		t.Errorf("text with context: '%s', expected: 'Otwórz menu'", text)
+	}
+	if text := TrC("toolbar", "open"); text != "open" {
+		t.Errorf("text with missing context: '%s', expected: 'open'", text)
+	}
+	// Fuzzy loading.
+	SetFuzzyLoading(true)
+	c = NewCatalog("pl")
+	if err := c.parsePO([]byte(testPO)); err != nil {
+		t.Fatalf("unable to parse PO data: %v", err)
+	}
+	if text, _ := c.Text("draft"); text != "Szkic" {
+		t.Errorf("fuzzy text: '%s', expected: 'Szkic'", text)
+	}
+}
+
+// TestParseKeyValue tests parsing of key=value data.
+func TestParseKeyValue(t *testing.T) {
+	c := NewCatalog("pl")
+	data := "# comment\nopen = Otwórz\nlines=first\\nsecond\nfile[0]=plik\nfile[2]=plików\n"
+	if err := c.parseKeyValue([]byte(data)); err != nil {
+		t.Fatalf("unable to parse key=value data: %v", err)
+	}
+	if text, _ := c.Text("open"); text != "Otwórz" {
+		t.Errorf("text: '%s', expected: 'Otwórz'", text)
+	}
+	if text, _ := c.Text("lines"); text != "first\nsecond" {
+		t.Errorf("text with new line: '%s', expected: 'first\\nsecond'", text)
+	}
+	if text, _ := c.PluralText("file", 5); text != "plików" {
+		t.Errorf("plural text: '%s', expected: 'plików'", text)
+	}
+	if err := c.parseKeyValue([]byte("no value\n")); err == nil {
+		t.Errorf("no error for line without '='")
+	}
+}
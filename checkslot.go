@@ -48,9 +48,16 @@ func NewCheckSlot(label string, value interface{}, bgSize pixel.Vec,
 	cs := new(CheckSlot)
 	cs.bgSize = bgSize
 	cs.bgColor = color
+	if cs.bgColor == nil {
+		cs.bgColor = theme.CheckSlot.MainColor
+	}
 	cs.checkColor = checkColor
+	if cs.checkColor == nil {
+		cs.checkColor = theme.CheckSlot.AccentColor
+	}
 	labelParams := Params{
-		FontSize: SizeMedium,
+		FontSize: theme.CheckSlot.FontSize,
+		Font:     theme.CheckSlot.Font,
 	}
 	cs.label = NewText(labelParams)
 	cs.label.SetText(label)
@@ -71,7 +78,7 @@ func (cs *CheckSlot) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates slot.
-func (cs *CheckSlot) Update(win *Window) {
+func (cs *CheckSlot) Update(win Input) {
 	// Mouse events.
 	if win.JustPressed(pixelgl.MouseButtonLeft) {
 		if cs.DrawArea().Contains(win.MousePosition()) {
@@ -87,6 +94,14 @@ func (cs *CheckSlot) Update(win *Window) {
 	}
 }
 
+// ApplyTheme sets colors from check slot style
+// of specified theme.
+func (cs *CheckSlot) ApplyTheme(t *Theme) {
+	cs.bgColor = t.CheckSlot.MainColor
+	cs.checkColor = t.CheckSlot.AccentColor
+	cs.label.ApplyTheme(t)
+}
+
 // SetSize sets specified vector as
 // background size.
 func (cs *CheckSlot) SetSize(s pixel.Vec) {
@@ -0,0 +1,143 @@
+/*
+ * completion.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for list of completion suggestions, displayed
+// under the text field.
+type suggestionList struct {
+	provider func(prefix string) []string
+	slots    []*CheckSlot
+	selected int // ID of highlighted suggestion, -1 if none
+}
+
+const (
+	// Maximal number of displayed suggestions.
+	maxSuggestions = 8
+)
+
+// newSuggestionList creates new empty suggestion list.
+func newSuggestionList() suggestionList {
+	return suggestionList{selected: -1}
+}
+
+// refresh replaces list content with suggestions from the
+// provider for specified prefix.
+// Suggestions equal to the prefix are skipped.
+// Specified size and colors are used for suggestion slots.
+func (sl *suggestionList) refresh(prefix string, size pixel.Vec, color, selColor color.Color) {
+	sl.hide()
+	if sl.provider == nil || len(prefix) < 1 {
+		return
+	}
+	for _, s := range sl.provider(prefix) {
+		if s == prefix {
+			continue
+		}
+		if len(sl.slots) >= maxSuggestions {
+			break
+		}
+		slot := NewCheckSlot(EscapeMarkup(s), s, size, color, selColor)
+		sl.slots = append(sl.slots, slot)
+	}
+}
+
+// hide removes all suggestions from the list.
+func (sl *suggestionList) hide() {
+	sl.slots = nil
+	sl.selected = -1
+}
+
+// visible checks if there are any suggestions in
+// the list.
+func (sl *suggestionList) visible() bool {
+	return len(sl.slots) > 0
+}
+
+// draw draws suggestions one under another, below
+// specified field area.
+func (sl *suggestionList) draw(t pixel.Target, fieldArea pixel.Rect) {
+	pos := pixel.V(fieldArea.Center().X, fieldArea.Min.Y)
+	for _, s := range sl.slots {
+		pos.Y -= s.Size().Y / 2
+		s.Draw(t, Matrix().Moved(pos))
+		pos.Y -= s.Size().Y / 2
+	}
+}
+
+// updateInput handles suggestion navigation with Up, Down
+// and Tab keys, and mouse hover.
+// Returns highlighted suggestion and true if Enter key was
+// pressed or suggestion was clicked.
+func (sl *suggestionList) updateInput(win Input) (string, bool) {
+	if !sl.visible() {
+		return "", false
+	}
+	switch {
+	case keyPressed(win, pixelgl.KeyUp), keyPressed(win, pixelgl.KeyTab) && shiftPressed(win):
+		sl.move(-1)
+	case keyPressed(win, pixelgl.KeyDown), keyPressed(win, pixelgl.KeyTab):
+		sl.move(1)
+	case win.JustPressed(pixelgl.KeyEscape):
+		sl.hide()
+		return "", false
+	}
+	for i, s := range sl.slots {
+		if s.DrawArea().Contains(win.MousePosition()) {
+			sl.selected = i
+			if win.JustPressed(pixelgl.MouseButtonLeft) {
+				return sl.choose()
+			}
+		}
+	}
+	for i, s := range sl.slots {
+		s.Check(i == sl.selected)
+	}
+	if sl.selected >= 0 && (win.JustPressed(pixelgl.KeyEnter) || win.JustPressed(pixelgl.KeyKPEnter)) {
+		return sl.choose()
+	}
+	return "", false
+}
+
+// move moves highlight by specified number of suggestions,
+// highlight wraps around the list ends.
+func (sl *suggestionList) move(n int) {
+	if sl.selected < 0 && n < 0 {
+		sl.selected = 0
+	}
+	sl.selected = (sl.selected + n + len(sl.slots)) % len(sl.slots)
+}
+
+// choose returns highlighted suggestion and hides the list.
+func (sl *suggestionList) choose() (string, bool) {
+	s, _ := sl.slots[sl.selected].Value().(string)
+	sl.hide()
+	return s, true
+}
@@ -43,6 +43,15 @@ func Scale() float64 {
 	return scale
 }
 
+// SetResolution calculates global scale for MTK elements for
+// specified resolution.
+// Scale is set automatically on new MTK window create, so this
+// function is useful only for updating UI elements without
+// window, e.g. with virtual input.
+func SetResolution(r pixel.Vec) {
+	initScale(r)
+}
+
 // DisBR returns bottom right position of specified rectangle
 // multiplied by specified value.
 func DisBR(rect pixel.Rect, scale float64) pixel.Vec {
@@ -0,0 +1,95 @@
+/*
+ * dynamicatlas.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"golang.org/x/image/font"
+
+	"github.com/gopxl/pixel/text"
+)
+
+// Struct for dynamic text atlas, that lazily adds
+// glyphs for new runes.
+// Atlas is rebuilt each time runes not present in the
+// atlas are added, texts using the previous version of
+// atlas should switch to the new one.
+type DynamicAtlas struct {
+	face    font.Face
+	runes   []rune
+	added   map[rune]bool
+	atlas   *text.Atlas
+	version int
+}
+
+// NewDynamicAtlas creates new dynamic atlas for specified
+// font face with glyphs for runes from specified rune sets.
+func NewDynamicAtlas(face font.Face, runeSets ...[]rune) *DynamicAtlas {
+	da := new(DynamicAtlas)
+	da.face = face
+	da.added = make(map[rune]bool)
+	for _, set := range runeSets {
+		da.addRunes(set)
+	}
+	da.atlas = text.NewAtlas(da.face, da.runes)
+	return da
+}
+
+// AddRunes adds glyphs for specified runes to the atlas.
+// Atlas is rebuilt only if at least one of specified runes
+// was not added before.
+// Runes not supported by the atlas font face are rendered
+// as replacement characters.
+func (da *DynamicAtlas) AddRunes(runes ...rune) {
+	if !da.addRunes(runes) {
+		return
+	}
+	da.atlas = text.NewAtlas(da.face, da.runes)
+	da.version++
+}
+
+// Atlas returns current version of the atlas.
+func (da *DynamicAtlas) Atlas() *text.Atlas {
+	return da.atlas
+}
+
+// Version returns number of atlas rebuilds.
+func (da *DynamicAtlas) Version() int {
+	return da.version
+}
+
+// addRunes adds specified runes to the atlas rune set.
+// Returns true if at least one rune was not added
+// before.
+func (da *DynamicAtlas) addRunes(runes []rune) bool {
+	added := false
+	for _, r := range runes {
+		if da.added[r] || r == '\n' || r == '\r' || r == '\t' {
+			continue
+		}
+		da.runes = append(da.runes, r)
+		da.added[r] = true
+		added = true
+	}
+	return added
+}
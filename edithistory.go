@@ -0,0 +1,148 @@
+/*
+ * edithistory.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Struct for edit history of text edit.
+// History stores states of text edit before each edit
+// step, consecutive edits of the same kind are grouped
+// into single step.
+type editHistory struct {
+	undo     []editState
+	redo     []editState
+	depth    int
+	lastKind editKind
+	lastPos  int  // caret position after the last edit
+	lastRune rune // last typed rune
+}
+
+// Struct for state of text edit.
+type editState struct {
+	text   string
+	caret  int
+	anchor int
+}
+
+// Type for kinds of text edits.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editTyping
+	editDelete
+	editPaste
+	editSet
+)
+
+// newEditHistory creates new edit history with
+// specified depth.
+func newEditHistory(depth int) editHistory {
+	return editHistory{depth: depth}
+}
+
+// record records specified state before edit of specified
+// kind, that inserts specified text and moves caret to
+// specified position.
+// Typing is grouped by words, deletions are grouped until
+// the caret is moved.
+func (h *editHistory) record(before editState, kind editKind, text string, pos int) {
+	grouped := h.lastKind == kind && len(h.undo) > 0 &&
+		before.caret == h.lastPos && before.caret == before.anchor
+	switch kind {
+	case editTyping:
+		// Space after word starts new step.
+		first, _ := utf8.DecodeRuneInString(text)
+		if unicode.IsSpace(first) && !unicode.IsSpace(h.lastRune) {
+			grouped = false
+		}
+		h.lastRune, _ = utf8.DecodeLastRuneInString(text)
+	case editDelete:
+	default:
+		grouped = false
+	}
+	if !grouped {
+		h.push(&h.undo, before)
+		h.redo = nil
+	}
+	h.lastKind = kind
+	h.lastPos = pos
+}
+
+// breakGroup ends current group of edits.
+func (h *editHistory) breakGroup() {
+	h.lastKind = editNone
+}
+
+// undoState returns state before the last edit step, and
+// stores specified current state for redo.
+// Returns false if there is nothing to undo.
+func (h *editHistory) undoState(current editState) (editState, bool) {
+	if len(h.undo) < 1 {
+		return current, false
+	}
+	state := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.push(&h.redo, current)
+	h.breakGroup()
+	return state, true
+}
+
+// redoState returns state after the last undone edit step,
+// and stores specified current state for undo.
+// Returns false if there is nothing to redo.
+func (h *editHistory) redoState(current editState) (editState, bool) {
+	if len(h.redo) < 1 {
+		return current, false
+	}
+	state := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.push(&h.undo, current)
+	h.breakGroup()
+	return state, true
+}
+
+// setDepth sets maximal number of stored edit steps.
+func (h *editHistory) setDepth(depth int) {
+	h.depth = depth
+	h.trim(&h.undo)
+	h.trim(&h.redo)
+}
+
+// push adds specified state to specified stack.
+func (h *editHistory) push(stack *[]editState, state editState) {
+	*stack = append(*stack, state)
+	h.trim(stack)
+}
+
+// trim removes the oldest states from specified stack,
+// so stack is not longer than history depth.
+func (h *editHistory) trim(stack *[]editState) {
+	if over := len(*stack) - max(h.depth, 0); over > 0 {
+		*stack = (*stack)[over:]
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ * edithistory_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"testing"
+)
+
+// testType types specified text after the caret of
+// specified state, and records each typed rune in
+// specified history.
+// Returns state after typing.
+func testType(h *editHistory, s editState, text string) editState {
+	for _, r := range text {
+		runes := []rune(s.text)
+		caret := s.caret + 1
+		h.record(s, editTyping, string(r), caret)
+		s = editState{string(runes[:s.caret]) + string(r) + string(runes[s.caret:]), caret, caret}
+	}
+	return s
+}
+
+// testDelete removes rune before the caret of specified
+// state, and records removal in specified history.
+// Returns state after removal.
+func testDelete(h *editHistory, s editState) editState {
+	runes := []rune(s.text)
+	caret := s.caret - 1
+	h.record(s, editDelete, "", caret)
+	return editState{string(runes[:caret]) + string(runes[s.caret:]), caret, caret}
+}
+
+// TestEditHistoryTyping tests grouping of typed text
+// by words.
+func TestEditHistoryTyping(t *testing.T) {
+	h := newEditHistory(defaultUndoDepth)
+	s := testType(&h, editState{}, "ab cd")
+	for _, expected := range []string{"ab", ""} {
+		state, ok := h.undoState(s)
+		if !ok || state.text != expected {
+			t.Fatalf("undo state: '%s', expected: '%s'", state.text, expected)
+		}
+		s = state
+	}
+	if _, ok := h.undoState(s); ok {
+		t.Fatalf("undo with empty history")
+	}
+	for _, expected := range []string{"ab", "ab cd"} {
+		state, ok := h.redoState(s)
+		if !ok || state.text != expected {
+			t.Fatalf("redo state: '%s', expected: '%s'", state.text, expected)
+		}
+		s = state
+	}
+	if state := (editState{"ab cd", 5, 5}); s != state {
+		t.Fatalf("state after redo: %v, expected: %v", s, state)
+	}
+}
+
+// TestEditHistoryDelete tests grouping of removals until
+// the caret is moved.
+func TestEditHistoryDelete(t *testing.T) {
+	h := newEditHistory(defaultUndoDepth)
+	s := testType(&h, editState{}, "abcd")
+	s = testDelete(&h, s)
+	s = testDelete(&h, s)
+	// Move caret and remove.
+	s.caret, s.anchor = 1, 1
+	s = testDelete(&h, s)
+	if s.text != "b" {
+		t.Fatalf("text after removals: '%s', expected: 'b'", s.text)
+	}
+	for _, expected := range []string{"ab", "abcd", ""} {
+		state, ok := h.undoState(s)
+		if !ok || state.text != expected {
+			t.Fatalf("undo state: '%s', expected: '%s'", state.text, expected)
+		}
+		s = state
+	}
+}
+
+// TestEditHistoryRedoReset tests removing redo steps
+// after new edit.
+func TestEditHistoryRedoReset(t *testing.T) {
+	h := newEditHistory(defaultUndoDepth)
+	s := testType(&h, editState{}, "ab")
+	s, _ = h.undoState(s)
+	if len(h.redo) != 1 {
+		t.Fatalf("redo steps after undo: %d, expected: 1", len(h.redo))
+	}
+	testType(&h, s, "c")
+	if len(h.redo) != 0 {
+		t.Fatalf("redo steps after edit: %d, expected: 0", len(h.redo))
+	}
+}
+
+// TestEditHistoryDepth tests limiting number of stored
+// edit steps.
+func TestEditHistoryDepth(t *testing.T) {
+	h := newEditHistory(2)
+	s := editState{}
+	for _, text := range []string{"a", "ab", "abc"} {
+		h.record(s, editPaste, text[len(s.text):], len(text))
+		s = editState{text, len(text), len(text)}
+	}
+	if len(h.undo) != 2 || h.undo[0].text != "a" {
+		t.Fatalf("undo steps: %v, expected 2 steps from 'a'", h.undo)
+	}
+	h.setDepth(1)
+	if len(h.undo) != 1 || h.undo[0].text != "ab" {
+		t.Fatalf("undo steps after depth change: %v, expected 1 step from 'ab'", h.undo)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for translating UI texts with translation
+// catalogs and switching language at runtime.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+var (
+	clicks int
+	text   *mtk.Text
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK localization example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Load translation catalogs.
+	en, err := mtk.LoadCatalog("en", "res/en.lang")
+	if err != nil {
+		panic(fmt.Errorf("Unable to load English catalog: %v", err))
+	}
+	mtk.AddCatalog(en)
+	pl, err := mtk.LoadCatalog("pl", "res/pl.po")
+	if err != nil {
+		panic(fmt.Errorf("Unable to load Polish catalog: %v", err))
+	}
+	mtk.AddCatalog(pl)
+	// Create UI elements with translated texts.
+	box := mtk.NewVBox(mtk.Params{})
+	box.SetSpacing(10)
+	text = mtk.NewText(mtk.Params{FontSize: mtk.SizeMedium})
+	text.SetTextKeyN("clicks", clicks, clicks)
+	box.Add(text)
+	buttonParams := mtk.Params{
+		Size:      mtk.SizeBig,
+		FontSize:  mtk.SizeMedium,
+		Shape:     mtk.ShapeRectangle,
+		MainColor: colornames.Red,
+	}
+	clickButton := mtk.NewButton(buttonParams)
+	clickButton.SetLabelKey("click")
+	clickButton.SetOnClickFunc(onClickButtonClicked)
+	box.Add(clickButton)
+	langButton := mtk.NewButton(buttonParams)
+	langButton.SetLabelKey("language")
+	langButton.SetOnClickFunc(onLangButtonClicked)
+	box.Add(langButton)
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw elements.
+		boxPos := win.Bounds().Center()
+		box.Draw(win, mtk.Matrix().Moved(boxPos))
+		// Update.
+		win.Update()
+		box.Update(win)
+	}
+}
+
+// onClickButtonClicked handles click button
+// click event.
+func onClickButtonClicked(b *mtk.Button) {
+	clicks++
+	text.SetTextKeyN("clicks", clicks, clicks)
+}
+
+// onLangButtonClicked handles language button
+// click event.
+func onLangButtonClicked(b *mtk.Button) {
+	if mtk.Language() == "pl" {
+		mtk.SetLanguage("en")
+		return
+	}
+	mtk.SetLanguage("pl")
+}
@@ -0,0 +1,90 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for building menu from XML layout file
+// and binding button callbacks by element IDs.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK layout example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Load menu layout.
+	layout, err := mtk.LoadLayout("res/menu.xml")
+	if err != nil {
+		panic(fmt.Errorf("Unable to load layout: %v", err))
+	}
+	// Bind callbacks.
+	err = layout.SetOnClickFunc("start", onStartButtonClicked)
+	if err != nil {
+		panic(fmt.Errorf("Unable to bind start button: %v", err))
+	}
+	err = layout.SetOnClickFunc("exit", func(b *mtk.Button) {
+		win.SetClosed(true)
+	})
+	if err != nil {
+		panic(fmt.Errorf("Unable to bind exit button: %v", err))
+	}
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw layout.
+		layoutPos := win.Bounds().Center()
+		layout.Draw(win, mtk.Matrix().Moved(layoutPos))
+		// Update.
+		win.Update()
+		layout.Update(win)
+	}
+}
+
+// onStartButtonClicked handles start button
+// click event.
+func onStartButtonClicked(b *mtk.Button) {
+	fmt.Println("Start!")
+}
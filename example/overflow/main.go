@@ -0,0 +1,91 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for buttons with too long labels and different
+// text overflow modes.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK text overflow example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Create buttons with different overflow modes
+	// in vertical box.
+	box := mtk.NewVBox(mtk.Params{})
+	box.SetSpacing(10)
+	overflows := []mtk.Overflow{
+		mtk.OverflowWrap,
+		mtk.OverflowEllipsis,
+		mtk.OverflowClip,
+		mtk.OverflowShrink,
+		mtk.OverflowMarquee,
+	}
+	for _, o := range overflows {
+		buttonParams := mtk.Params{
+			Size:      mtk.SizeBig,
+			FontSize:  mtk.SizeMedium,
+			Shape:     mtk.ShapeRectangle,
+			MainColor: colornames.Red,
+			Overflow:  o,
+		}
+		button := mtk.NewButton(buttonParams)
+		button.SetLabel("Button with very long label")
+		box.Add(button)
+	}
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw buttons.
+		boxPos := win.Bounds().Center()
+		box.Draw(win, mtk.Matrix().Moved(boxPos))
+		// Update.
+		win.Update()
+		box.Update(win)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for creating MTK panel with buttons.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK panel example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Create panel.
+	panelParams := mtk.Params{
+		SizeRaw:   mtk.ConvVec(pixel.V(400, 300)),
+		MainColor: colornames.Grey,
+	}
+	panel := mtk.NewPanel(panelParams)
+	// Create buttons and add them to the panel.
+	buttonParams := mtk.Params{
+		Size:      mtk.SizeMedium,
+		FontSize:  mtk.SizeMedium,
+		Shape:     mtk.ShapeRectangle,
+		MainColor: colornames.Red,
+	}
+	okButton := mtk.NewButton(buttonParams)
+	okButton.SetLabel("OK")
+	okButton.SetOnClickFunc(onButtonClicked)
+	panel.Add(okButton, mtk.MoveBR(panel.Size(), okButton.Size()))
+	exitButton := mtk.NewButton(buttonParams)
+	exitButton.SetLabel("Exit")
+	exitButton.SetOnClickFunc(func(b *mtk.Button) { win.SetClosed(true) })
+	panel.Add(exitButton, mtk.MoveBL(panel.Size(), exitButton.Size()))
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw panel with all buttons.
+		panelPos := win.Bounds().Center()
+		panel.Draw(win, mtk.Matrix().Moved(panelPos))
+		// Update.
+		win.Update()
+		panel.Update(win)
+	}
+}
+
+// onButtonClicked handles button click
+// event.
+func onButtonClicked(b *mtk.Button) {
+	fmt.Println("Click!")
+}
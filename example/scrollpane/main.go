@@ -0,0 +1,97 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example of creating and using MTK scroll pane.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK scroll pane example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Create rows of buttons bigger than the pane.
+	rows := mtk.NewVBox(mtk.Params{})
+	rows.SetSpacing(10)
+	rows.SetPadding(10)
+	buttonParams := mtk.Params{
+		Size:      mtk.SizeBig,
+		FontSize:  mtk.SizeMedium,
+		Shape:     mtk.ShapeRectangle,
+		MainColor: colornames.Red,
+	}
+	for i := 1; i <= 10; i++ {
+		row := mtk.NewHBox(mtk.Params{})
+		row.SetSpacing(10)
+		for j := 1; j <= 6; j++ {
+			label := fmt.Sprintf("Button %d-%d", i, j)
+			button := mtk.NewButton(buttonParams)
+			button.SetLabel(label)
+			button.SetOnClickFunc(func(b *mtk.Button) {
+				fmt.Printf("Click: %s\n", label)
+			})
+			row.Add(button)
+		}
+		rows.Add(row)
+	}
+	// Create scroll pane.
+	paneParams := mtk.Params{
+		SizeRaw:   mtk.ConvVec(pixel.V(800, 500)),
+		MainColor: colornames.Grey,
+	}
+	pane := mtk.NewScrollPane(paneParams)
+	pane.SetContent(rows)
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw scroll pane.
+		pane.Draw(win, mtk.Matrix().Moved(win.Bounds().Center()))
+		// Update.
+		win.Update()
+		pane.Update(win)
+	}
+}
@@ -71,7 +71,20 @@ func run() {
 		MainColor: colornames.White,
 	}
 	text := mtk.NewText(textParams)
-	text.SetText("Hello MTK!\nnew line\ntooo loooooooooong linnnnnne")
+	text.SetText("Hello [color=red]MTK[/color]!\n[b]bold[/b] and [i]italic[/i]\ntooo loooooooooong linnnnnne")
+	// Create text with effects.
+	effectsParams := textParams
+	effectsParams.TextEffects = mtk.TextEffects{
+		OutlineColor:     colornames.Blue,
+		OutlineThickness: 1,
+		ShadowColor:      colornames.Gray,
+		ShadowOffset:     pixel.V(3, -3),
+		Gradient:         mtk.GradientHorizontal,
+		GradientStart:    colornames.Yellow,
+		GradientEnd:      colornames.Red,
+	}
+	effectsText := mtk.NewText(effectsParams)
+	effectsText.SetText("Outline, shadow\nand gradient")
 	// Main loop.
 	for !win.Closed() {
 		// Clear window.
@@ -79,6 +92,8 @@ func run() {
 		// Draw text.
 		textPos := win.Bounds().Center()
 		text.Draw(win, mtk.Matrix().Moved(textPos))
+		effectsTextPos := textPos.Sub(pixel.V(0, mtk.ConvSize(100)))
+		effectsText.Draw(win, mtk.Matrix().Moved(effectsTextPos))
 		// Update.
 		win.Update()
 	}
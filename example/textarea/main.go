@@ -0,0 +1,79 @@
+/*
+ * main.go
+ *
+ * Copyright 2022 Dariusz Sikora <dev@isangeles.pl>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example of creating and using MTK text area.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK text area example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Create text area.
+	params := mtk.Params{
+		FontSize: mtk.SizeMedium,
+		SizeRaw:  mtk.ConvVec(pixel.V(600, 300)),
+	}
+	textArea := mtk.NewTextArea(params)
+	textArea.SetText("Journal\n\nDay 1: Arrived at the village. " +
+		"The innkeeper mentioned strange lights in the forest to the north.")
+	textArea.Focus(true)
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw text area.
+		textArea.Draw(win, mtk.Matrix().Moved(win.Bounds().Center()))
+		// Update.
+		win.Update()
+		textArea.Update(win)
+		// Key events.
+		if win.JustPressed(pixelgl.KeyS) && win.Pressed(pixelgl.KeyLeftControl) {
+			fmt.Printf("Journal:\n%s\n", textArea.Text())
+		}
+	}
+}
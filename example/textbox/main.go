@@ -96,16 +96,45 @@ keys!
 	textbox.AddText("line 11\n")
 	textbox.AddText("line 12\n")
 	textbox.ScrollBottom()
+	// Create textbox for combat log.
+	logbox := mtk.NewTextbox(textboxParams)
+	logbox.SetMaxLines(100)
+	logbox.SetTimestampFormat("15:04:05")
+	logbox.SetCategoryColor("combat", colornames.Red)
+	logbox.SetCategoryColor("chat", colornames.Lightblue)
+	logbox.AddLog("chat", "Hello!\n")
+	logbox.AddLog("combat", "Goblin hits you for 5 damage.\n")
+	logbox.AddLog("chat", "Need help?\n")
+	logbox.AddLog("combat", "You hit goblin for 8 damage.\n")
+	logbox.AddText("Goblin dies.\n")
+	filtered := false
 	// Main loop.
 	for !win.Closed() {
 		// Clear window.
 		win.Clear(colornames.Black)
 		// Draw textbox.
-		textboxPos := win.Bounds().Center()
+		textboxPos := win.Bounds().Center().Sub(pixel.V(mtk.ConvSize(200), 0))
 		textbox.Draw(win, mtk.Matrix().Moved(textboxPos))
+		logboxPos := win.Bounds().Center().Add(pixel.V(mtk.ConvSize(200), 0))
+		logbox.Draw(win, mtk.Matrix().Moved(logboxPos))
 		// Update.
 		win.Update()
 		textbox.Update(win) // update makes scrolling possible
+		logbox.Update(win)
+		// Key events.
+		if win.JustPressed(pixelgl.KeyF) {
+			// Find next line with 'goblin'.
+			logbox.Find("goblin")
+		}
+		if win.JustPressed(pixelgl.KeyC) {
+			// Toggle combat filter.
+			filtered = !filtered
+			if filtered {
+				logbox.SetCategoryFilter("combat")
+			} else {
+				logbox.SetCategoryFilter()
+			}
+		}
 	}
 }
 
@@ -28,6 +28,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
+	"strings"
 
 	"golang.org/x/image/colornames"
 
@@ -71,6 +73,21 @@ func run() {
 		SizeRaw:   pixel.V(200, 30),
 	}
 	textedit := mtk.NewTextedit(params)
+	textedit.SetPlaceholder("Name")
+	textedit.SetMaxLength(16)
+	// Create textedit for numbers.
+	numberEdit := mtk.NewTextedit(params)
+	numberEdit.SetPlaceholder("Age")
+	numberEdit.SetFilters(mtk.FilterDigits)
+	numberEdit.SetValidator(validateAge)
+	// Create textedit for passwords.
+	passwordEdit := mtk.NewTextedit(params)
+	passwordEdit.SetPlaceholder("Password")
+	passwordEdit.SetPasswordMask('*')
+	// Create textedit with command completion.
+	commandEdit := mtk.NewTextedit(params)
+	commandEdit.SetPlaceholder("Command")
+	commandEdit.SetSuggestionProvider(suggestCommands)
 	// Main loop.
 	for !win.Closed() {
 		// Clear window.
@@ -78,9 +95,18 @@ func run() {
 		// Draw textedit.
 		texteditPos := win.Bounds().Center()
 		textedit.Draw(win, mtk.Matrix().Moved(texteditPos))
+		numberEditPos := texteditPos.Sub(pixel.V(0, mtk.ConvSize(50)))
+		numberEdit.Draw(win, mtk.Matrix().Moved(numberEditPos))
+		passwordEditPos := numberEditPos.Sub(pixel.V(0, mtk.ConvSize(50)))
+		passwordEdit.Draw(win, mtk.Matrix().Moved(passwordEditPos))
+		commandEditPos := passwordEditPos.Sub(pixel.V(0, mtk.ConvSize(50)))
+		commandEdit.Draw(win, mtk.Matrix().Moved(commandEditPos))
 		// Update.
 		win.Update()
 		textedit.Update(win)
+		numberEdit.Update(win)
+		passwordEdit.Update(win)
+		commandEdit.Update(win)
 		// Key events.
 		if win.JustPressed(pixelgl.KeyEnter) {
 			fmt.Printf("Input: %s\n", textedit.Text())
@@ -88,6 +114,30 @@ func run() {
 	}
 }
 
+// validateAge checks if specified text is
+// a valid age.
+func validateAge(text string) error {
+	if len(text) < 1 {
+		return nil
+	}
+	age, err := strconv.Atoi(text)
+	if err != nil || age < 1 || age > 150 {
+		return fmt.Errorf("Age must be a number from 1 to 150")
+	}
+	return nil
+}
+
+// suggestCommands returns all console commands
+// with specified prefix.
+func suggestCommands(prefix string) (commands []string) {
+	for _, c := range []string{"give", "goto", "godmode", "help", "heal", "quit"} {
+		if strings.HasPrefix(c, prefix) {
+			commands = append(commands, c)
+		}
+	}
+	return
+}
+
 // loadFont reads font file from specified path
 // and returns font face or error if file was
 // not found.
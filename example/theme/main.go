@@ -0,0 +1,94 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for loading UI theme from JSON file and
+// applying it to UI elements.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK theme example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Load and set UI theme.
+	theme, err := mtk.LoadTheme("res/theme.json")
+	if err != nil {
+		panic(fmt.Errorf("Unable to load theme: %v", err))
+	}
+	mtk.SetTheme(theme)
+	// Create UI elements, all colors are taken from
+	// the theme.
+	box := mtk.NewVBox(mtk.Params{})
+	box.SetPadding(10)
+	box.SetSpacing(10)
+	buttonParams := mtk.Params{
+		Size:     mtk.SizeBig,
+		FontSize: mtk.SizeMedium,
+		Shape:    mtk.ShapeRectangle,
+	}
+	button := mtk.NewButton(buttonParams)
+	button.SetLabel("Themed")
+	button.SetInfo("Themed info")
+	box.Add(button)
+	textParams := mtk.Params{
+		FontSize: mtk.SizeMedium,
+	}
+	text := mtk.NewText(textParams)
+	text.SetText("Themed text")
+	box.Add(text)
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw elements.
+		boxPos := win.Bounds().Center()
+		box.Draw(win, mtk.Matrix().Moved(boxPos))
+		// Update.
+		win.Update()
+		box.Update(win)
+	}
+}
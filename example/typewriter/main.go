@@ -0,0 +1,86 @@
+/*
+ * main.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+// Example for revealing text character by character,
+// with pauses, like in dialogue windows.
+// Click on the text, or press space with mouse cursor
+// over the text, to reveal whole text.
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+
+	"github.com/isangeles/mtk"
+)
+
+// Main function.
+func main() {
+	// Run Pixel graphic.
+	pixelgl.Run(run)
+}
+
+// All window code fired from there.
+func run() {
+	// Create Pixel window configuration.
+	cfg := pixelgl.WindowConfig{
+		Title:  "MTK typewriter example",
+		Bounds: pixel.R(0, 0, 1600, 900),
+	}
+	// Create MTK warpper for Pixel window.
+	win, err := mtk.NewWindow(cfg)
+	if err != nil {
+		panic(fmt.Errorf("Unable to create MTK window: %v", err))
+	}
+	// Create text revealed with 20 characters per second.
+	textParams := mtk.Params{
+		FontSize:  mtk.SizeBig,
+		SizeRaw:   pixel.V(600, 0), // max width
+		MainColor: colornames.White,
+	}
+	text := mtk.NewText(textParams)
+	text.SetRevealSpeed(20)
+	text.SetOnRevealFinishedFunc(onRevealFinished)
+	text.SetText("Hello traveler...[pause=1000] I've been waiting for you.[pause=500]\n[color=red]Welcome to MTK![/color]")
+	// Main loop.
+	for !win.Closed() {
+		// Clear window.
+		win.Clear(colornames.Black)
+		// Draw text.
+		textPos := win.Bounds().Center()
+		text.Draw(win, mtk.Matrix().Moved(textPos))
+		// Update.
+		win.Update()
+		text.Update(win) // update reveals next characters
+	}
+}
+
+// onRevealFinished handles text reveal
+// finish event.
+func onRevealFinished(t *mtk.Text) {
+	fmt.Println("Text revealed!")
+}
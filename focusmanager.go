@@ -0,0 +1,407 @@
+/*
+ * focusmanager.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for focus manager, that moves user focus between
+// focusable UI elements.
+// Tab/Shift+Tab cycles focus through elements of active
+// focus group, Ctrl+Tab/Ctrl+Shift+Tab switches active group,
+// arrow keys move focus to the nearest element in the arrow
+// direction.
+// Disabled elements are skipped.
+type FocusManager struct {
+	focus          *Focus
+	groups         []*FocusGroup
+	groupID        int // active group
+	arrows         bool
+	indicator      bool
+	indicatorColor color.Color
+	indicatorWidth float64
+	indicatorFunc  func(t pixel.Target, drawArea pixel.Rect)
+}
+
+// Struct for ordered group of focusable elements.
+type FocusGroup struct {
+	elements []Focuser
+}
+
+// Interface for UI elements that can be disabled.
+type disabler interface {
+	Disabled() bool
+}
+
+// Interface for UI elements with popups, like suggestion
+// lists, that use navigation keys while opened.
+type popupElement interface {
+	PopupOpen() bool
+}
+
+// Interface for UI elements that use arrow keys while
+// focused, like text editors.
+type arrowKeysElement interface {
+	UsesArrowKeys() bool
+}
+
+// Interface for UI elements with draw area.
+type drawAreaElement interface {
+	DrawArea() pixel.Rect
+}
+
+// NewFocusGroup creates new focus group with specified
+// elements.
+func NewFocusGroup(elements ...Focuser) *FocusGroup {
+	fg := new(FocusGroup)
+	fg.elements = elements
+	return fg
+}
+
+// Add adds specified elements at the end of the group.
+func (fg *FocusGroup) Add(elements ...Focuser) {
+	fg.elements = append(fg.elements, elements...)
+}
+
+// Remove removes specified element from the group.
+func (fg *FocusGroup) Remove(e Focuser) {
+	for i, ge := range fg.elements {
+		if ge == e {
+			fg.elements = append(fg.elements[:i], fg.elements[i+1:]...)
+			return
+		}
+	}
+}
+
+// Elements returns all group elements.
+func (fg *FocusGroup) Elements() []Focuser {
+	return fg.elements
+}
+
+// NewFocusManager creates new focus manager for
+// specified focus.
+func NewFocusManager(focus *Focus) *FocusManager {
+	fm := new(FocusManager)
+	fm.focus = focus
+	fm.arrows = true
+	fm.indicator = true
+	fm.indicatorColor = colornames.Gold
+	fm.indicatorWidth = 2
+	return fm
+}
+
+// Draw draws focus indicator around currently
+// focused element.
+func (fm *FocusManager) Draw(t pixel.Target) {
+	if !fm.indicator {
+		return
+	}
+	e, ok := fm.focus.Element().(drawAreaElement)
+	if !ok {
+		return
+	}
+	if fm.indicatorFunc != nil {
+		fm.indicatorFunc(t, e.DrawArea())
+		return
+	}
+	DrawRectBorder(t, e.DrawArea(), fm.indicatorColor,
+		ConvSize(fm.indicatorWidth))
+}
+
+// Update handles key events.
+// Keys are not handled while focused element has
+// opened popup, arrow keys are not handled while focused
+// element uses them, like text edit or text area.
+func (fm *FocusManager) Update(win Input) {
+	fm.syncFocus()
+	if p, ok := fm.focus.Element().(popupElement); ok && p.PopupOpen() {
+		return
+	}
+	shift := win.Pressed(pixelgl.KeyLeftShift) || win.Pressed(pixelgl.KeyRightShift)
+	ctrl := win.Pressed(pixelgl.KeyLeftControl) || win.Pressed(pixelgl.KeyRightControl)
+	if win.JustPressed(pixelgl.KeyTab) || win.Repeated(pixelgl.KeyTab) {
+		switch {
+		case ctrl && shift:
+			fm.PrevGroup()
+		case ctrl:
+			fm.NextGroup()
+		case shift:
+			fm.Prev()
+		default:
+			fm.Next()
+		}
+	}
+	if !fm.arrows {
+		return
+	}
+	if a, ok := fm.focus.Element().(arrowKeysElement); ok && a.UsesArrowKeys() {
+		return
+	}
+	switch {
+	case win.JustPressed(pixelgl.KeyUp):
+		fm.Move(pixel.V(0, 1))
+	case win.JustPressed(pixelgl.KeyDown):
+		fm.Move(pixel.V(0, -1))
+	case win.JustPressed(pixelgl.KeyLeft):
+		fm.Move(pixel.V(-1, 0))
+	case win.JustPressed(pixelgl.KeyRight):
+		fm.Move(pixel.V(1, 0))
+	}
+}
+
+// AddGroup adds specified focus group to the manager.
+func (fm *FocusManager) AddGroup(g *FocusGroup) {
+	fm.groups = append(fm.groups, g)
+}
+
+// RemoveGroup removes specified focus group from
+// the manager.
+func (fm *FocusManager) RemoveGroup(g *FocusGroup) {
+	for i, mg := range fm.groups {
+		if mg == g {
+			fm.groups = append(fm.groups[:i], fm.groups[i+1:]...)
+			break
+		}
+	}
+	if fm.groupID >= len(fm.groups) {
+		fm.groupID = 0
+	}
+}
+
+// Groups returns all manager focus groups.
+func (fm *FocusManager) Groups() []*FocusGroup {
+	return fm.groups
+}
+
+// ActiveGroup returns active focus group or nil if
+// manager has no groups.
+func (fm *FocusManager) ActiveGroup() *FocusGroup {
+	if fm.groupID < 0 || fm.groupID > len(fm.groups)-1 {
+		return nil
+	}
+	return fm.groups[fm.groupID]
+}
+
+// SetActiveGroup sets specified group as active focus
+// group and focuses first enabled element of this group.
+func (fm *FocusManager) SetActiveGroup(g *FocusGroup) {
+	for i, mg := range fm.groups {
+		if mg == g {
+			fm.activateGroup(i)
+			return
+		}
+	}
+}
+
+// NextGroup activates next focus group.
+func (fm *FocusManager) NextGroup() {
+	fm.activateGroup(fm.groupID + 1)
+}
+
+// PrevGroup activates previous focus group.
+func (fm *FocusManager) PrevGroup() {
+	fm.activateGroup(fm.groupID - 1)
+}
+
+// Next moves focus to the next enabled element of
+// active focus group.
+func (fm *FocusManager) Next() {
+	fm.cycle(1)
+}
+
+// Prev moves focus to the previous enabled element of
+// active focus group.
+func (fm *FocusManager) Prev() {
+	fm.cycle(-1)
+}
+
+// Move moves focus to the nearest enabled element of active
+// focus group in specified direction, based on elements
+// draw areas.
+func (fm *FocusManager) Move(dir pixel.Vec) {
+	group := fm.ActiveGroup()
+	if group == nil {
+		return
+	}
+	current, ok := fm.focus.Element().(drawAreaElement)
+	if !ok || !fm.inGroup(fm.focus.Element()) {
+		fm.cycle(1)
+		return
+	}
+	from := current.DrawArea().Center()
+	dir = dir.Unit()
+	var (
+		nearest   Focuser
+		bestScore = math.Inf(1)
+	)
+	for _, e := range group.elements {
+		if e == fm.focus.Element() || !focusable(e) {
+			continue
+		}
+		ae, ok := e.(drawAreaElement)
+		if !ok || ae.DrawArea() == pixel.ZR {
+			continue
+		}
+		v := ae.DrawArea().Center().Sub(from)
+		along := v.Dot(dir)
+		if along <= 0 {
+			continue
+		}
+		across := math.Abs(v.Cross(dir))
+		score := along + across*2
+		if score < bestScore {
+			nearest = e
+			bestScore = score
+		}
+	}
+	if nearest != nil {
+		fm.focus.Focus(nearest)
+	}
+}
+
+// SetArrowNavigation toggles focus movement with arrow keys.
+// Arrow navigation should be disabled if focused elements
+// use arrow keys by themselves, like lists or text boxes.
+// Text edits and text areas keep arrow keys while focused.
+func (fm *FocusManager) SetArrowNavigation(arrows bool) {
+	fm.arrows = arrows
+}
+
+// ShowIndicator toggles focus indicator drawing.
+func (fm *FocusManager) ShowIndicator(show bool) {
+	fm.indicator = show
+}
+
+// SetIndicatorColor sets specified color as focus
+// indicator color.
+func (fm *FocusManager) SetIndicatorColor(c color.Color) {
+	fm.indicatorColor = c
+}
+
+// SetIndicatorWidth sets specified value(for 1080p) as
+// focus indicator border width.
+func (fm *FocusManager) SetIndicatorWidth(width float64) {
+	fm.indicatorWidth = width
+}
+
+// SetIndicatorFunc sets specified function as function used
+// to draw focus indicator around draw area of focused element.
+// Nil function restores default border indicator.
+func (fm *FocusManager) SetIndicatorFunc(f func(t pixel.Target, drawArea pixel.Rect)) {
+	fm.indicatorFunc = f
+}
+
+// activateGroup sets group with specified index as active
+// group and focuses first enabled element of this group.
+// If specified index is bigger than last group index then
+// first group is activated, if is smaller than 0 then last
+// group is activated.
+func (fm *FocusManager) activateGroup(id int) {
+	if len(fm.groups) < 1 {
+		return
+	}
+	switch {
+	case id > len(fm.groups)-1:
+		fm.groupID = 0
+	case id < 0:
+		fm.groupID = len(fm.groups) - 1
+	default:
+		fm.groupID = id
+	}
+	fm.focus.Focus(nil)
+	fm.cycle(1)
+}
+
+// cycle moves focus by specified number of enabled elements
+// in active focus group, wrapping around group ends.
+func (fm *FocusManager) cycle(step int) {
+	group := fm.ActiveGroup()
+	if group == nil || len(group.elements) < 1 {
+		return
+	}
+	count := len(group.elements)
+	start := -1
+	for i, e := range group.elements {
+		if e == fm.focus.Element() {
+			start = i
+			break
+		}
+	}
+	if start < 0 && step < 0 {
+		start = count
+	}
+	for i := 1; i <= count; i++ {
+		id := ((start+step*i)%count + count) % count
+		e := group.elements[id]
+		if focusable(e) {
+			fm.focus.Focus(e)
+			return
+		}
+	}
+}
+
+// syncFocus sets element from active group that focused
+// itself(e.g. after mouse click) as currently focused
+// element.
+func (fm *FocusManager) syncFocus() {
+	group := fm.ActiveGroup()
+	if group == nil {
+		return
+	}
+	for _, e := range group.elements {
+		if e != fm.focus.Element() && e.Focused() {
+			fm.focus.Focus(e)
+			return
+		}
+	}
+}
+
+// inGroup checks if specified element belongs to the active
+// focus group.
+func (fm *FocusManager) inGroup(e Focuser) bool {
+	group := fm.ActiveGroup()
+	if group == nil {
+		return false
+	}
+	for _, ge := range group.elements {
+		if ge == e {
+			return true
+		}
+	}
+	return false
+}
+
+// focusable checks whether specified element can
+// be focused.
+func focusable(e Focuser) bool {
+	d, ok := e.(disabler)
+	return !ok || !d.Disabled()
+}
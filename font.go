@@ -0,0 +1,262 @@
+/*
+ * font.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"fmt"
+	"image"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/golang/freetype/truetype"
+)
+
+// Struct for registered font, with cached font faces
+// and atlases for all used sizes.
+type fontEntry struct {
+	font      *truetype.Font
+	fallbacks []string
+	faces     map[Size]font.Face
+	atlases   map[Size]*DynamicAtlas
+}
+
+// Struct for font face with fallback faces, used for
+// glyphs missing in the main face.
+type fallbackFace struct {
+	faces []font.Face
+	fonts []*truetype.Font
+}
+
+// defaultFont is used when no main font is registered.
+var defaultFont = newFontEntry(nil)
+
+// RegisterFont registers specified truetype font under
+// specified name, e.g. 'sans' or 'sans-bold'.
+// Registered font can be used by UI elements with font
+// name in parameters or in theme style.
+// Font registered under main font name is used as main
+// font of the interface.
+func RegisterFont(name string, f *truetype.Font) {
+	entry := newFontEntry(f)
+	if old := fonts[name]; old != nil {
+		entry.fallbacks = old.fallbacks
+	}
+	fonts[name] = entry
+	clearFontCache()
+}
+
+// LoadFont loads truetype font from file with specified
+// path.
+func LoadFont(path string) (*truetype.Font, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read font file: %v", err)
+	}
+	f, err := truetype.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse font: %v", err)
+	}
+	return f, nil
+}
+
+// SetFontFallbacks sets fonts with specified names as
+// fallback fonts for font with specified name.
+// Glyphs missing in the font are taken from the first
+// fallback font that contains them.
+func SetFontFallbacks(name string, fallbacks ...string) {
+	entry := fonts[name]
+	if entry == nil {
+		return
+	}
+	entry.fallbacks = fallbacks
+	clearFontCache()
+}
+
+// Font returns face of font with specified name in
+// specified size.
+// Returns face of main font if there is no font with
+// specified name.
+// Font faces are cached, so all UI elements share the
+// same face for the same font and size.
+func Font(name string, s Size) font.Face {
+	entry := findFont(name)
+	face := entry.faces[s]
+	if face == nil {
+		face = entry.newFace(s)
+		entry.faces[s] = face
+	}
+	return face
+}
+
+// FontAtlas returns dynamic atlas for font with specified
+// name in specified size.
+// Returns atlas of main font if there is no font with
+// specified name.
+// Atlas is shared by all UI texts with the same font and
+// size.
+func FontAtlas(name string, s Size) *DynamicAtlas {
+	entry := findFont(name)
+	atlas := entry.atlases[s]
+	if atlas == nil {
+		atlas = NewDynamicAtlas(Font(name, s), runeSets...)
+		entry.atlases[s] = atlas
+	}
+	return atlas
+}
+
+// fontRegistered checks whether font with specified
+// name is registered.
+func fontRegistered(name string) bool {
+	return fonts[name] != nil
+}
+
+// newFontEntry creates new font registry entry for
+// specified font.
+func newFontEntry(f *truetype.Font) *fontEntry {
+	entry := new(fontEntry)
+	entry.font = f
+	entry.faces = make(map[Size]font.Face)
+	entry.atlases = make(map[Size]*DynamicAtlas)
+	return entry
+}
+
+// newFace creates new face of font in specified size,
+// with fallback fonts faces.
+func (fe *fontEntry) newFace(s Size) font.Face {
+	if fe.font == nil {
+		return fallbackFont
+	}
+	opts := truetype.Options{Size: fontPoints(s)}
+	face := truetype.NewFace(fe.font, &opts)
+	if len(fe.fallbacks) < 1 {
+		return face
+	}
+	ff := &fallbackFace{
+		faces: []font.Face{face},
+		fonts: []*truetype.Font{fe.font},
+	}
+	for _, name := range fe.fallbacks {
+		fallback := fonts[name]
+		if fallback == nil || fallback.font == nil {
+			continue
+		}
+		ff.faces = append(ff.faces, truetype.NewFace(fallback.font, &opts))
+		ff.fonts = append(ff.fonts, fallback.font)
+	}
+	return ff
+}
+
+// Close closes all faces.
+func (ff *fallbackFace) Close() error {
+	for _, f := range ff.faces {
+		f.Close()
+	}
+	return nil
+}
+
+// Glyph returns glyph for specified rune from the first
+// face that contains it.
+func (ff *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image,
+	image.Point, fixed.Int26_6, bool) {
+	return ff.face(r).Glyph(dot, r)
+}
+
+// GlyphBounds returns bounds of glyph for specified rune
+// from the first face that contains it.
+func (ff *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return ff.face(r).GlyphBounds(r)
+}
+
+// GlyphAdvance returns advance of glyph for specified rune
+// from the first face that contains it.
+func (ff *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return ff.face(r).GlyphAdvance(r)
+}
+
+// Kern returns kerning for specified runes from the main
+// face.
+func (ff *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	return ff.faces[0].Kern(r0, r1)
+}
+
+// Metrics returns metrics of the main face.
+func (ff *fallbackFace) Metrics() font.Metrics {
+	return ff.faces[0].Metrics()
+}
+
+// face returns first face that contains glyph for
+// specified rune, or the main face if there is no
+// such face.
+func (ff *fallbackFace) face(r rune) font.Face {
+	for i, f := range ff.fonts {
+		if f.Index(r) != 0 {
+			return ff.faces[i]
+		}
+	}
+	return ff.faces[0]
+}
+
+// findFont returns registry entry for font with specified
+// name, main font entry if there is no such font, or
+// default font entry if there is no main font.
+func findFont(name string) *fontEntry {
+	if entry := fonts[name]; entry != nil {
+		return entry
+	}
+	if entry := fonts[MainFontName]; entry != nil {
+		return entry
+	}
+	return defaultFont
+}
+
+// clearFontCache removes all cached font faces and
+// atlases.
+// UI elements created before keep using their faces
+// and atlases.
+func clearFontCache() {
+	for _, entry := range fonts {
+		entry.faces = make(map[Size]font.Face)
+		entry.atlases = make(map[Size]*DynamicAtlas)
+	}
+	defaultFont.atlases = make(map[Size]*DynamicAtlas)
+}
+
+// fontPoints returns font size in points for specified
+// UI size.
+func fontPoints(s Size) float64 {
+	switch {
+	case s <= SizeMini:
+		return 10
+	case s == SizeSmall:
+		return 15
+	case s == SizeMedium:
+		return 20
+	case s >= SizeBig:
+		return 30
+	default:
+		return 10
+	}
+}
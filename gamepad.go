@@ -0,0 +1,210 @@
+/*
+ * gamepad.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"sort"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Interface for gamepad input sources, like MTK window.
+type GamepadInput interface {
+	JoystickPresent(js pixelgl.Joystick) bool
+	JoystickPressed(js pixelgl.Joystick, button pixelgl.GamepadButton) bool
+	JoystickJustPressed(js pixelgl.Joystick, button pixelgl.GamepadButton) bool
+	JoystickJustReleased(js pixelgl.Joystick, button pixelgl.GamepadButton) bool
+	JoystickAxis(js pixelgl.Joystick, axis pixelgl.GamepadAxis) float64
+}
+
+// Struct for gamepad, that translates gamepad buttons and
+// axes to user actions.
+// Actions are passed to the focused element first, navigation
+// actions not handled by the focused element move focus.
+type Gamepad struct {
+	joystick pixelgl.Joystick
+	focus    *FocusManager
+	buttons  map[pixelgl.GamepadButton]Action
+	axes     map[pixelgl.GamepadAxis][2]Action
+	axisDirs map[pixelgl.GamepadAxis]int // last direction of each axis
+	deadzone float64
+	onAction func(a Action)
+}
+
+// NewGamepad creates new gamepad for specified joystick
+// with default action table.
+// Specified focus manager is used to move focus between
+// UI elements, can be nil.
+func NewGamepad(joystick pixelgl.Joystick, focus *FocusManager) *Gamepad {
+	g := new(Gamepad)
+	g.joystick = joystick
+	g.focus = focus
+	g.deadzone = 0.5
+	g.axisDirs = make(map[pixelgl.GamepadAxis]int)
+	g.ResetActions()
+	return g
+}
+
+// Update triggers actions for gamepad buttons and axes
+// pressed in the current frame.
+// Gamepad is not updated if specified input source does
+// not support gamepads.
+func (g *Gamepad) Update(win Input) {
+	gi, ok := win.(GamepadInput)
+	if !ok || !gi.JoystickPresent(g.joystick) {
+		return
+	}
+	for _, a := range g.actions(gi) {
+		g.Trigger(a)
+	}
+}
+
+// Trigger triggers specified action.
+func (g *Gamepad) Trigger(a Action) {
+	if g.focus != nil {
+		e := g.focus.focus.Element()
+		if h, ok := e.(ActionHandler); ok && h.HandleAction(a) {
+			return
+		}
+		switch a {
+		case ActionUp:
+			g.focus.Move(pixel.V(0, 1))
+			return
+		case ActionDown:
+			g.focus.Move(pixel.V(0, -1))
+			return
+		case ActionLeft:
+			g.focus.Move(pixel.V(-1, 0))
+			return
+		case ActionRight:
+			g.focus.Move(pixel.V(1, 0))
+			return
+		}
+	}
+	if g.onAction != nil {
+		g.onAction(a)
+	}
+}
+
+// SetButtonAction maps specified gamepad button to
+// specified action.
+func (g *Gamepad) SetButtonAction(button pixelgl.GamepadButton, a Action) {
+	g.buttons[button] = a
+}
+
+// RemoveButtonAction removes action mapping for
+// specified gamepad button.
+func (g *Gamepad) RemoveButtonAction(button pixelgl.GamepadButton) {
+	delete(g.buttons, button)
+}
+
+// SetAxisActions maps negative and positive directions
+// of specified gamepad axis to specified actions.
+func (g *Gamepad) SetAxisActions(axis pixelgl.GamepadAxis, negative, positive Action) {
+	g.axes[axis] = [2]Action{negative, positive}
+}
+
+// RemoveAxisActions removes action mapping for
+// specified gamepad axis.
+func (g *Gamepad) RemoveAxisActions(axis pixelgl.GamepadAxis) {
+	delete(g.axes, axis)
+}
+
+// ResetActions restores default action table:
+// D-pad and left stick for navigation, A/B buttons for
+// accept/cancel, shoulder buttons for scrolling.
+func (g *Gamepad) ResetActions() {
+	g.buttons = map[pixelgl.GamepadButton]Action{
+		pixelgl.ButtonDpadUp:      ActionUp,
+		pixelgl.ButtonDpadDown:    ActionDown,
+		pixelgl.ButtonDpadLeft:    ActionLeft,
+		pixelgl.ButtonDpadRight:   ActionRight,
+		pixelgl.ButtonA:           ActionAccept,
+		pixelgl.ButtonB:           ActionCancel,
+		pixelgl.ButtonLeftBumper:  ActionScrollUp,
+		pixelgl.ButtonRightBumper: ActionScrollDown,
+	}
+	g.axes = map[pixelgl.GamepadAxis][2]Action{
+		pixelgl.AxisLeftX: {ActionLeft, ActionRight},
+		pixelgl.AxisLeftY: {ActionUp, ActionDown},
+	}
+}
+
+// SetDeadzone sets specified value as minimal axis
+// value that triggers axis action.
+func (g *Gamepad) SetDeadzone(deadzone float64) {
+	g.deadzone = deadzone
+}
+
+// SetOnActionFunc sets specified function as function
+// triggered for each action not handled by the focused
+// element or focus movement.
+func (g *Gamepad) SetOnActionFunc(f func(a Action)) {
+	g.onAction = f
+}
+
+// actions returns all actions triggered in the current
+// frame by specified gamepad input.
+// Button actions are returned first, in order of button
+// codes, then axis actions, in order of axis codes.
+// Axis action is triggered only when axis leaves the
+// deadzone.
+func (g *Gamepad) actions(gi GamepadInput) (actions []Action) {
+	buttons := make([]pixelgl.GamepadButton, 0, len(g.buttons))
+	for b := range g.buttons {
+		buttons = append(buttons, b)
+	}
+	sort.Slice(buttons, func(i, j int) bool { return buttons[i] < buttons[j] })
+	for _, b := range buttons {
+		if gi.JoystickJustPressed(g.joystick, b) {
+			actions = append(actions, g.buttons[b])
+		}
+	}
+	axes := make([]pixelgl.GamepadAxis, 0, len(g.axes))
+	for axis := range g.axes {
+		axes = append(axes, axis)
+	}
+	sort.Slice(axes, func(i, j int) bool { return axes[i] < axes[j] })
+	for _, axis := range axes {
+		a := g.axes[axis]
+		dir := 0
+		value := gi.JoystickAxis(g.joystick, axis)
+		switch {
+		case value <= -g.deadzone:
+			dir = -1
+		case value >= g.deadzone:
+			dir = 1
+		}
+		if dir != 0 && dir != g.axisDirs[axis] {
+			if dir < 0 {
+				actions = append(actions, a[0])
+			} else {
+				actions = append(actions, a[1])
+			}
+		}
+		g.axisDirs[axis] = dir
+	}
+	return
+}
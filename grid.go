@@ -0,0 +1,383 @@
+/*
+ * grid.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for grid layouts, containers that place their
+// children in cells of rows and columns.
+// Rows and columns have fixed size or are sized to fit
+// the biggest element inside them.
+// Positions of children are calculated on each draw, so
+// layout is always up to date with current children sizes.
+type Grid struct {
+	bgSpr      *pixel.Sprite
+	size       pixel.Vec
+	color      color.Color
+	drawArea   pixel.Rect // updated on each draw
+	colWidths  map[int]float64
+	rowHeights map[int]float64
+	colGap     float64
+	rowGap     float64
+	padding    float64
+	alignH     Align
+	alignV     Align
+	cells      []*gridCell
+}
+
+// Struct for grid cell with element.
+type gridCell struct {
+	widget  Widget
+	row     int
+	col     int
+	rowSpan int
+	colSpan int
+	alignH  Align
+	alignV  Align
+}
+
+// NewGrid creates new grid layout with specified parameters.
+// If raw size is not specified then grid size is calculated
+// from sizes of its rows and columns.
+func NewGrid(params Params) *Grid {
+	g := new(Grid)
+	g.size = params.SizeRaw
+	g.color = params.MainColor
+	if g.color == nil {
+		g.color = theme.Panel.MainColor
+	}
+	g.bgSpr = params.Background
+	if g.bgSpr == nil {
+		g.bgSpr = theme.Panel.Background
+	}
+	g.colWidths = make(map[int]float64)
+	g.rowHeights = make(map[int]float64)
+	g.alignH = AlignCenter
+	g.alignV = AlignCenter
+	return g
+}
+
+// Draw draws grid background and all grid children.
+func (g *Grid) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Calculating draw area.
+	g.drawArea = MatrixToDrawArea(matrix, g.Size())
+	// Background.
+	if g.bgSpr != nil {
+		g.bgSpr.Draw(t, matrix)
+	} else if g.color != nil {
+		DrawRect(t, g.DrawArea(), g.color)
+	}
+	// Children.
+	size := g.Size()
+	widths, heights := g.tracks()
+	for _, c := range g.cells {
+		move := g.cellMove(c, size, widths, heights)
+		c.widget.Draw(t, matrix.Moved(move))
+	}
+}
+
+// Update updates all grid children.
+func (g *Grid) Update(win Input) {
+	for _, c := range g.cells {
+		c.widget.Update(win)
+	}
+}
+
+// Add adds specified element to the grid cell with
+// specified row and column indexes.
+func (g *Grid) Add(w Widget, row, col int) {
+	g.AddSpan(w, row, col, 1, 1)
+}
+
+// AddSpan adds specified element to the grid cell with
+// specified row and column indexes, that spans over
+// specified number of rows and columns.
+// Negative indexes are set to 0, spans smaller than 1
+// are set to 1.
+func (g *Grid) AddSpan(w Widget, row, col, rowSpan, colSpan int) {
+	row = max(0, row)
+	col = max(0, col)
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	if colSpan < 1 {
+		colSpan = 1
+	}
+	c := gridCell{
+		widget:  w,
+		row:     row,
+		col:     col,
+		rowSpan: rowSpan,
+		colSpan: colSpan,
+		alignH:  g.alignH,
+		alignV:  g.alignV,
+	}
+	g.cells = append(g.cells, &c)
+}
+
+// Remove removes specified element from the grid.
+func (g *Grid) Remove(w Widget) {
+	for i, c := range g.cells {
+		if c.widget == w {
+			g.cells = append(g.cells[:i], g.cells[i+1:]...)
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the grid.
+func (g *Grid) Clear() {
+	g.cells = nil
+}
+
+// Children returns all grid elements.
+func (g *Grid) Children() []Widget {
+	children := make([]Widget, len(g.cells))
+	for i, c := range g.cells {
+		children[i] = c.widget
+	}
+	return children
+}
+
+// SetColumnWidth sets specified value(for 1080p) as fixed
+// width of column with specified index.
+// Value <= 0 makes column auto-sized.
+func (g *Grid) SetColumnWidth(col int, width float64) {
+	if width <= 0 {
+		delete(g.colWidths, col)
+		return
+	}
+	g.colWidths[col] = width
+}
+
+// SetRowHeight sets specified value(for 1080p) as fixed
+// height of row with specified index.
+// Value <= 0 makes row auto-sized.
+func (g *Grid) SetRowHeight(row int, height float64) {
+	if height <= 0 {
+		delete(g.rowHeights, row)
+		return
+	}
+	g.rowHeights[row] = height
+}
+
+// SetGaps sets specified values(for 1080p) as space
+// between grid columns and rows.
+func (g *Grid) SetGaps(colGap, rowGap float64) {
+	g.colGap = colGap
+	g.rowGap = rowGap
+}
+
+// SetPadding sets specified value(for 1080p) as
+// space between grid edges and its content.
+func (g *Grid) SetPadding(padding float64) {
+	g.padding = padding
+}
+
+// SetAlign sets default horizontal and vertical align
+// of elements inside grid cells.
+// Default align is used for all elements added after
+// this call.
+func (g *Grid) SetAlign(h, v Align) {
+	g.alignH = h
+	g.alignV = v
+}
+
+// SetCellAlign sets horizontal and vertical align of
+// specified element inside its grid cell.
+func (g *Grid) SetCellAlign(w Widget, h, v Align) {
+	for _, c := range g.cells {
+		if c.widget == w {
+			c.alignH = h
+			c.alignV = v
+			return
+		}
+	}
+}
+
+// ApplyTheme sets color and background from panel style
+// of specified theme.
+// Theme is also applied to all grid elements.
+func (g *Grid) ApplyTheme(t *Theme) {
+	g.color = t.Panel.MainColor
+	g.bgSpr = t.Panel.Background
+	applyChildrenTheme(g.Children(), t)
+}
+
+// SetBackground sets specified sprite as grid
+// background, also removes background color.
+func (g *Grid) SetBackground(s *pixel.Sprite) {
+	g.bgSpr = s
+	g.color = nil
+}
+
+// SetColor sets specified color as grid
+// background color.
+func (g *Grid) SetColor(c color.Color) {
+	g.color = c
+}
+
+// SetSize sets grid size.
+func (g *Grid) SetSize(s pixel.Vec) {
+	g.size = s
+}
+
+// Size returns grid size.
+func (g *Grid) Size() pixel.Vec {
+	if g.bgSpr != nil {
+		return g.bgSpr.Frame().Size()
+	}
+	if g.size != pixel.ZV {
+		return g.size
+	}
+	widths, heights := g.tracks()
+	padding := ConvSize(g.padding)
+	size := pixel.V(padding*2, padding*2)
+	size.X += spanSize(widths, 0, len(widths), ConvSize(g.colGap))
+	size.Y += spanSize(heights, 0, len(heights), ConvSize(g.rowGap))
+	return size
+}
+
+// DrawArea returns current grid draw area.
+func (g *Grid) DrawArea() pixel.Rect {
+	return g.drawArea
+}
+
+// tracks calculates widths of all grid columns and
+// heights of all grid rows.
+func (g *Grid) tracks() (widths, heights []float64) {
+	cols, rows := 0, 0
+	for _, c := range g.cells {
+		cols = max(cols, c.col+c.colSpan)
+		rows = max(rows, c.row+c.rowSpan)
+	}
+	widths = make([]float64, cols)
+	heights = make([]float64, rows)
+	for col, w := range g.colWidths {
+		if col >= 0 && col < cols {
+			widths[col] = ConvSize(w)
+		}
+	}
+	for row, h := range g.rowHeights {
+		if row >= 0 && row < rows {
+			heights[row] = ConvSize(h)
+		}
+	}
+	// Single cells first, then cells with spans.
+	for _, c := range g.cells {
+		size := c.widget.Size()
+		if c.colSpan == 1 && g.colWidths[c.col] <= 0 {
+			widths[c.col] = math.Max(widths[c.col], size.X)
+		}
+		if c.rowSpan == 1 && g.rowHeights[c.row] <= 0 {
+			heights[c.row] = math.Max(heights[c.row], size.Y)
+		}
+	}
+	for _, c := range g.cells {
+		size := c.widget.Size()
+		if c.colSpan > 1 {
+			g.growSpan(widths, g.colWidths, c.col, c.colSpan, size.X, ConvSize(g.colGap))
+		}
+		if c.rowSpan > 1 {
+			g.growSpan(heights, g.rowHeights, c.row, c.rowSpan, size.Y, ConvSize(g.rowGap))
+		}
+	}
+	return
+}
+
+// growSpan grows auto-sized tracks in specified span so
+// the span can fit element with specified size.
+// Missing space is divided equally between auto-sized
+// tracks.
+func (g *Grid) growSpan(tracks []float64, fixed map[int]float64, start, span int,
+	size, gap float64) {
+	missing := size - spanSize(tracks, start, span, gap)
+	if missing <= 0 {
+		return
+	}
+	var auto []int
+	for i := start; i < start+span; i++ {
+		if fixed[i] <= 0 {
+			auto = append(auto, i)
+		}
+	}
+	for _, i := range auto {
+		tracks[i] += missing / float64(len(auto))
+	}
+}
+
+// cellMove returns move vector from the grid center to draw
+// position(center) of specified cell element, for grid with
+// specified size and tracks.
+func (g *Grid) cellMove(c *gridCell, size pixel.Vec, widths, heights []float64) pixel.Vec {
+	padding := ConvSize(g.padding)
+	colGap := ConvSize(g.colGap)
+	rowGap := ConvSize(g.rowGap)
+	// Cell area.
+	minX := -size.X/2 + padding + spanSize(widths, 0, c.col, colGap)
+	if c.col > 0 {
+		minX += colGap
+	}
+	maxY := size.Y/2 - padding - spanSize(heights, 0, c.row, rowGap)
+	if c.row > 0 {
+		maxY -= rowGap
+	}
+	cellW := spanSize(widths, c.col, c.colSpan, colGap)
+	cellH := spanSize(heights, c.row, c.rowSpan, rowGap)
+	// Element position inside cell.
+	childSize := c.widget.Size()
+	move := pixel.V(minX+cellW/2, maxY-cellH/2)
+	switch c.alignH {
+	case AlignLeft:
+		move.X = minX + childSize.X/2
+	case AlignRight:
+		move.X = minX + cellW - childSize.X/2
+	}
+	switch c.alignV {
+	case AlignTop:
+		move.Y = maxY - childSize.Y/2
+	case AlignBottom:
+		move.Y = maxY - cellH + childSize.Y/2
+	}
+	return move
+}
+
+// spanSize returns total size of specified number of tracks,
+// starting from track with specified index, with specified
+// gaps between tracks.
+func spanSize(tracks []float64, start, span int, gap float64) float64 {
+	size := 0.0
+	for i := start; i < start+span && i < len(tracks); i++ {
+		if i > start {
+			size += gap
+		}
+		size += tracks[i]
+	}
+	return size
+}
@@ -26,13 +26,14 @@ package mtk
 import (
 	"image/color"
 
-	"golang.org/x/image/colornames"
-
 	"github.com/gopxl/pixel"
 )
 
 // InfoWindow struct for small text boxes that
 // follows mouse cursor.
+// Info window is not a widget, its position is set
+// by mouse position on update, so it's drawn without
+// a matrix.
 type InfoWindow struct {
 	*Text
 	bgColor  color.Color
@@ -43,12 +44,14 @@ type InfoWindow struct {
 func NewInfoWindow(params Params) *InfoWindow {
 	iw := new(InfoWindow)
 	textParams := Params{
-		FontSize: params.FontSize,
+		FontSize:    params.FontSize,
+		Font:        params.Font,
+		TextEffects: params.TextEffects,
 	}
 	iw.Text = NewText(textParams)
-	iw.bgColor = colornames.Black
-	if params.MainColor != nil {
-		iw.bgColor = params.MainColor
+	iw.bgColor = params.MainColor
+	if iw.bgColor == nil {
+		iw.bgColor = theme.InfoWindow.MainColor
 	}
 	return iw
 }
@@ -61,12 +64,25 @@ func (iw *InfoWindow) Draw(t pixel.Target) {
 }
 
 // Update updates info window.
-func (iw *InfoWindow) Update(win *Window) {
+func (iw *InfoWindow) Update(win Input) {
 	iw.drawArea = pixel.R(win.MousePosition().X, win.MousePosition().Y,
 		win.MousePosition().X+iw.Size().X,
 		win.MousePosition().Y+iw.Size().Y)
 }
 
+// ApplyTheme sets background color from info window
+// style of specified theme.
+func (iw *InfoWindow) ApplyTheme(t *Theme) {
+	iw.bgColor = t.InfoWindow.MainColor
+}
+
+// applyElementTheme sets background color from info window
+// style of specified theme, for info window of other
+// UI element.
+func (iw *InfoWindow) applyElementTheme(t *Theme) {
+	iw.bgColor = t.InfoWindow.SecColor
+}
+
 // DrawArea returns the bounds of latest info window draw area.
 func (iw *InfoWindow) DrawArea() pixel.Rect {
 	return iw.drawArea
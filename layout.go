@@ -0,0 +1,528 @@
+/*
+ * layout.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for UI layout, tree of UI elements built from
+// layout file.
+// Layout elements are accessible by IDs specified in the
+// layout file.
+type Layout struct {
+	root     Widget
+	elements map[string]Widget
+}
+
+// Struct for layout element data in layout file.
+// In XML files element type is specified by the tag
+// name, in JSON files by the type field.
+// Sizes and offsets are values for 1080p.
+type layoutElementData struct {
+	XMLName     xml.Name             `json:"-"`
+	Type        string               `xml:"-" json:"type"`
+	ID          string               `xml:"id,attr" json:"id"`
+	Size        string               `xml:"size,attr" json:"size"`
+	FontSize    string               `xml:"font-size,attr" json:"font-size"`
+	Font        string               `xml:"font,attr" json:"font"`
+	Shape       string               `xml:"shape,attr" json:"shape"`
+	Width       float64              `xml:"width,attr" json:"width"`
+	Height      float64              `xml:"height,attr" json:"height"`
+	MainColor   string               `xml:"main-color,attr" json:"main-color"`
+	SecColor    string               `xml:"sec-color,attr" json:"sec-color"`
+	AccentColor string               `xml:"accent-color,attr" json:"accent-color"`
+	Background  string               `xml:"background,attr" json:"background"`
+	Label       string               `xml:"label,attr" json:"label"`
+	Info        string               `xml:"info,attr" json:"info"`
+	Text        string               `xml:"text,attr" json:"text"`
+	LabelKey    string               `xml:"label-key,attr" json:"label-key"`
+	InfoKey     string               `xml:"info-key,attr" json:"info-key"`
+	TextKey     string               `xml:"text-key,attr" json:"text-key"`
+	Items       []string             `xml:"item" json:"items"`
+	X           float64              `xml:"x,attr" json:"x"`
+	Y           float64              `xml:"y,attr" json:"y"`
+	Anchor      string               `xml:"anchor,attr" json:"anchor"`
+	Stretch     string               `xml:"stretch,attr" json:"stretch"`
+	Row         int                  `xml:"row,attr" json:"row"`
+	Column      int                  `xml:"column,attr" json:"column"`
+	RowSpan     int                  `xml:"row-span,attr" json:"row-span"`
+	ColumnSpan  int                  `xml:"column-span,attr" json:"column-span"`
+	Padding     float64              `xml:"padding,attr" json:"padding"`
+	Spacing     float64              `xml:"spacing,attr" json:"spacing"`
+	ColumnGap   float64              `xml:"column-gap,attr" json:"column-gap"`
+	RowGap      float64              `xml:"row-gap,attr" json:"row-gap"`
+	Align       string               `xml:"align,attr" json:"align"`
+	VAlign      string               `xml:"v-align,attr" json:"v-align"`
+	Children    []*layoutElementData `xml:",any" json:"children"`
+}
+
+var (
+	shapeNames = map[string]Shape{
+		"rectangle": ShapeRectangle,
+		"square":    ShapeSquare,
+	}
+	alignNames = map[string]Align{
+		"center": AlignCenter,
+		"right":  AlignRight,
+		"left":   AlignLeft,
+		"top":    AlignTop,
+		"bottom": AlignBottom,
+	}
+	anchorNames = map[string]Anchor{
+		"center":       AnchorCenter,
+		"top":          AnchorTop,
+		"top-right":    AnchorTopRight,
+		"right":        AnchorRight,
+		"bottom-right": AnchorBottomRight,
+		"bottom":       AnchorBottom,
+		"bottom-left":  AnchorBottomLeft,
+		"left":         AnchorLeft,
+		"top-left":     AnchorTopLeft,
+	}
+	stretchNames = map[string]Stretch{
+		"none":       StretchNone,
+		"horizontal": StretchHorizontal,
+		"vertical":   StretchVertical,
+		"both":       StretchBoth,
+	}
+)
+
+// LoadLayout builds UI layout from JSON or XML file with
+// specified path.
+// Supported element types: panel, hbox, vbox, grid,
+// anchor-layout, scroll-pane, button, text, textbox, textedit,
+// text-area, switch, list and progress-bar.
+// Scroll pane can contain only one child element.
+// Label, info and text keys in the file are used as
+// translation keys for element texts.
+// Background paths in the file are relative to the layout
+// file directory.
+func LoadLayout(path string) (*Layout, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file: %v", err)
+	}
+	data := new(layoutElementData)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(file, data)
+	case ".xml":
+		err = xml.Unmarshal(file, data)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal layout data: %v", err)
+	}
+	l := new(Layout)
+	l.elements = make(map[string]Widget)
+	l.root, err = l.buildElement(data, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build layout: %v", err)
+	}
+	return l, nil
+}
+
+// Draw draws root element of the layout.
+func (l *Layout) Draw(t pixel.Target, matrix pixel.Matrix) {
+	l.root.Draw(t, matrix)
+}
+
+// Update updates root element of the layout.
+func (l *Layout) Update(win Input) {
+	l.root.Update(win)
+}
+
+// Size returns size of the layout root element.
+func (l *Layout) Size() pixel.Vec {
+	return l.root.Size()
+}
+
+// DrawArea returns current draw area of the layout
+// root element.
+func (l *Layout) DrawArea() pixel.Rect {
+	return l.root.DrawArea()
+}
+
+// Root returns root element of the layout.
+func (l *Layout) Root() Widget {
+	return l.root
+}
+
+// Element returns layout element with specified ID
+// or nil if there is no such element.
+func (l *Layout) Element(id string) Widget {
+	return l.elements[id]
+}
+
+// Button returns layout button with specified ID or
+// nil if there is no such button.
+func (l *Layout) Button(id string) *Button {
+	b, _ := l.elements[id].(*Button)
+	return b
+}
+
+// Text returns layout text with specified ID or nil
+// if there is no such text.
+func (l *Layout) Text(id string) *Text {
+	t, _ := l.elements[id].(*Text)
+	return t
+}
+
+// Textbox returns layout textbox with specified ID or
+// nil if there is no such textbox.
+func (l *Layout) Textbox(id string) *Textbox {
+	t, _ := l.elements[id].(*Textbox)
+	return t
+}
+
+// Textedit returns layout text edit with specified ID
+// or nil if there is no such text edit.
+func (l *Layout) Textedit(id string) *Textedit {
+	t, _ := l.elements[id].(*Textedit)
+	return t
+}
+
+// TextArea returns layout text area with specified ID
+// or nil if there is no such text area.
+func (l *Layout) TextArea(id string) *TextArea {
+	t, _ := l.elements[id].(*TextArea)
+	return t
+}
+
+// Switch returns layout switch with specified ID or
+// nil if there is no such switch.
+func (l *Layout) Switch(id string) *Switch {
+	s, _ := l.elements[id].(*Switch)
+	return s
+}
+
+// List returns layout list with specified ID or nil
+// if there is no such list.
+func (l *Layout) List(id string) *List {
+	list, _ := l.elements[id].(*List)
+	return list
+}
+
+// ProgressBar returns layout progress bar with specified
+// ID or nil if there is no such progress bar.
+func (l *Layout) ProgressBar(id string) *ProgressBar {
+	pb, _ := l.elements[id].(*ProgressBar)
+	return pb
+}
+
+// SetOnClickFunc sets specified function as on-click
+// function of layout button with specified ID.
+func (l *Layout) SetOnClickFunc(id string, f func(b *Button)) error {
+	b := l.Button(id)
+	if b == nil {
+		return fmt.Errorf("button not found: %s", id)
+	}
+	b.SetOnClickFunc(f)
+	return nil
+}
+
+// SetOnChangeFunc sets specified function as on-change
+// function of layout switch with specified ID.
+func (l *Layout) SetOnChangeFunc(id string, f func(s *Switch, old, new *SwitchValue)) error {
+	s := l.Switch(id)
+	if s == nil {
+		return fmt.Errorf("switch not found: %s", id)
+	}
+	s.SetOnChangeFunc(f)
+	return nil
+}
+
+// SetOnItemSelectFunc sets specified function as on-item-select
+// function of layout list with specified ID.
+func (l *Layout) SetOnItemSelectFunc(id string, f func(i *CheckSlot)) error {
+	list := l.List(id)
+	if list == nil {
+		return fmt.Errorf("list not found: %s", id)
+	}
+	list.SetOnItemSelectFunc(f)
+	return nil
+}
+
+// buildElement creates new UI element, with all its children,
+// from specified element data.
+// Paths in data are resolved relative to specified directory.
+func (l *Layout) buildElement(data *layoutElementData, dir string) (Widget, error) {
+	params, err := layoutParams(data, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", data.ID, err)
+	}
+	elementType := data.Type
+	if len(elementType) < 1 {
+		elementType = data.XMLName.Local
+	}
+	var element Widget
+	switch elementType {
+	case "panel":
+		element = NewPanel(params)
+	case "scroll-pane":
+		element = NewScrollPane(params)
+	case "hbox", "vbox":
+		box := newBox(params)
+		box.horizontal = elementType == "hbox"
+		box.SetPadding(data.Padding)
+		box.SetSpacing(data.Spacing)
+		if len(data.Align) > 0 {
+			align, err := parseAlign(data.Align)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", data.ID, err)
+			}
+			box.SetAlign(align)
+		}
+		element = box
+	case "grid":
+		grid := NewGrid(params)
+		grid.SetPadding(data.Padding)
+		grid.SetGaps(data.ColumnGap, data.RowGap)
+		alignH, err := parseAlign(data.Align)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", data.ID, err)
+		}
+		alignV, err := parseAlign(data.VAlign)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", data.ID, err)
+		}
+		grid.SetAlign(alignH, alignV)
+		element = grid
+	case "anchor-layout":
+		element = NewAnchorLayout(params)
+	case "button":
+		button := NewButton(params)
+		button.SetLabel(data.Label)
+		button.SetInfo(data.Info)
+		if len(data.LabelKey) > 0 {
+			button.SetLabelKey(data.LabelKey)
+		}
+		if len(data.InfoKey) > 0 {
+			button.SetInfoKey(data.InfoKey)
+		}
+		element = button
+	case "text":
+		text := NewText(params)
+		text.SetText(data.Text)
+		if len(data.TextKey) > 0 {
+			text.SetTextKey(data.TextKey)
+		}
+		element = text
+	case "textbox":
+		textbox := NewTextbox(params)
+		textbox.SetText(data.Text)
+		if len(data.TextKey) > 0 {
+			textbox.SetTextKey(data.TextKey)
+		}
+		element = textbox
+	case "textedit":
+		textedit := NewTextedit(params)
+		textedit.SetText(data.Text)
+		element = textedit
+	case "text-area":
+		textArea := NewTextArea(params)
+		textArea.SetText(data.Text)
+		element = textArea
+	case "switch":
+		sw := NewSwitch(params)
+		sw.SetLabel(data.Label)
+		sw.SetInfo(data.Info)
+		if len(data.LabelKey) > 0 {
+			sw.SetLabelKey(data.LabelKey)
+		}
+		if len(data.InfoKey) > 0 {
+			sw.SetInfoKey(data.InfoKey)
+		}
+		values := make([]SwitchValue, len(data.Items))
+		for i, item := range data.Items {
+			values[i] = SwitchValue{item, item}
+		}
+		sw.SetValues(values...)
+		element = sw
+	case "list":
+		list := NewList(params)
+		for _, item := range data.Items {
+			list.AddItem(item, item)
+		}
+		element = list
+	case "progress-bar":
+		pb := NewProgressBar(params.Size, params.MainColor)
+		pb.SetLabel(data.Label)
+		element = pb
+	default:
+		return nil, fmt.Errorf("%s: unknown element type: %s", data.ID, elementType)
+	}
+	if len(data.ID) > 0 {
+		if _, ok := l.elements[data.ID]; ok {
+			return nil, fmt.Errorf("duplicate element ID: %s", data.ID)
+		}
+		l.elements[data.ID] = element
+	}
+	for _, childData := range data.Children {
+		child, err := l.buildElement(childData, dir)
+		if err != nil {
+			return nil, err
+		}
+		err = addLayoutChild(element, child, childData)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", data.ID, err)
+		}
+	}
+	return element, nil
+}
+
+// addLayoutChild adds specified child element to specified
+// container element, with placement from specified child
+// element data.
+func addLayoutChild(container, child Widget, data *layoutElementData) error {
+	switch c := container.(type) {
+	case *Panel:
+		c.Add(child, ConvVec(pixel.V(data.X, data.Y)))
+	case *Box:
+		c.Add(child)
+	case *ScrollPane:
+		if c.Content() != nil {
+			return fmt.Errorf("scroll pane already has content")
+		}
+		c.SetContent(child)
+	case *Grid:
+		c.AddSpan(child, data.Row, data.Column, data.RowSpan, data.ColumnSpan)
+	case *AnchorLayout:
+		anchor, err := parseAnchor(data.Anchor)
+		if err != nil {
+			return err
+		}
+		c.Add(child, anchor, pixel.V(data.X, data.Y))
+		stretch, err := parseStretch(data.Stretch)
+		if err != nil {
+			return err
+		}
+		c.SetStretch(child, stretch)
+	default:
+		return fmt.Errorf("element is not a container")
+	}
+	return nil
+}
+
+// parseAlign parses specified align name to align.
+// Empty name is parsed to center align.
+func parseAlign(name string) (Align, error) {
+	if len(name) < 1 {
+		return AlignCenter, nil
+	}
+	align, ok := alignNames[name]
+	if !ok {
+		return AlignCenter, fmt.Errorf("unknown align name: %s", name)
+	}
+	return align, nil
+}
+
+// parseAnchor parses specified anchor name to anchor.
+// Empty name is parsed to center anchor.
+func parseAnchor(name string) (Anchor, error) {
+	if len(name) < 1 {
+		return AnchorCenter, nil
+	}
+	anchor, ok := anchorNames[name]
+	if !ok {
+		return AnchorCenter, fmt.Errorf("unknown anchor name: %s", name)
+	}
+	return anchor, nil
+}
+
+// parseStretch parses specified stretch name to stretch.
+// Empty name is parsed to no stretch.
+func parseStretch(name string) (Stretch, error) {
+	if len(name) < 1 {
+		return StretchNone, nil
+	}
+	stretch, ok := stretchNames[name]
+	if !ok {
+		return StretchNone, fmt.Errorf("unknown stretch name: %s", name)
+	}
+	return stretch, nil
+}
+
+// layoutParams creates UI element parameters from specified
+// element data.
+// Paths in data are resolved relative to specified directory.
+func layoutParams(data *layoutElementData, dir string) (params Params, err error) {
+	if len(data.Size) > 0 {
+		params.Size, err = ParseSize(data.Size)
+		if err != nil {
+			return
+		}
+	}
+	if len(data.FontSize) > 0 {
+		params.FontSize, err = ParseSize(data.FontSize)
+		if err != nil {
+			return
+		}
+	}
+	if len(data.Shape) > 0 {
+		shape, ok := shapeNames[data.Shape]
+		if !ok {
+			return params, fmt.Errorf("unknown shape name: %s", data.Shape)
+		}
+		params.Shape = shape
+	}
+	params.SizeRaw = ConvVec(pixel.V(data.Width, data.Height))
+	if len(data.MainColor) > 0 {
+		params.MainColor, err = ParseColor(data.MainColor)
+		if err != nil {
+			return
+		}
+	}
+	if len(data.SecColor) > 0 {
+		params.SecColor, err = ParseColor(data.SecColor)
+		if err != nil {
+			return
+		}
+	}
+	if len(data.AccentColor) > 0 {
+		params.AccentColor, err = ParseColor(data.AccentColor)
+		if err != nil {
+			return
+		}
+	}
+	if len(data.Background) > 0 {
+		params.Background, err = loadSprite(filepath.Join(dir, data.Background))
+		if err != nil {
+			return params, fmt.Errorf("unable to load background: %v", err)
+		}
+	}
+	params.Font = data.Font
+	params.Label = data.Label
+	params.Info = data.Info
+	return
+}
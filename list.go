@@ -40,8 +40,12 @@ type List struct {
 	drawArea         pixel.Rect
 	upButton         *Button
 	downButton       *Button
+	scrollbar        *Scrollbar
+	wheel            wheelScroll
 	items            []*CheckSlot
 	startIndex       int
+	highlight        int
+	visible          int
 	selectedVal      interface{}
 	focused          bool
 	disabled         bool
@@ -54,20 +58,40 @@ func NewList(params Params) *List {
 	l := new(List)
 	// Background.
 	l.bgSize = params.SizeRaw
+	l.bgSpr = params.Background
+	if l.bgSpr == nil {
+		l.bgSpr = theme.List.Background
+	}
 	l.bgColor = params.MainColor
+	if l.bgColor == nil {
+		l.bgColor = theme.List.MainColor
+	}
 	l.secColor = params.SecColor
+	if l.secColor == nil {
+		l.secColor = theme.List.SecColor
+	}
 	l.accentColor = params.AccentColor
+	if l.accentColor == nil {
+		l.accentColor = theme.List.AccentColor
+	}
 	// Buttons.
 	buttonParams := Params{
-		Size:      SizeMini,
-		FontSize:  SizeMedium,
-		Shape:     ShapeSquare,
-		MainColor: params.AccentColor,
+		Size:       theme.ScrollButton.Size,
+		FontSize:   theme.ScrollButton.FontSize,
+		Shape:      ShapeSquare,
+		MainColor:  l.accentColor,
+		Background: theme.ScrollButton.Background,
+	}
+	if buttonParams.MainColor == nil {
+		buttonParams.MainColor = theme.ScrollButton.MainColor
 	}
 	l.upButton = NewButton(buttonParams)
 	l.upButton.SetOnClickFunc(l.onButtonUpClicked)
 	l.downButton = NewButton(buttonParams)
 	l.downButton.SetOnClickFunc(l.onButtonDownClicked)
+	// Scrollbar.
+	l.scrollbar = NewScrollbar(Params{AccentColor: l.accentColor})
+	l.scrollbar.SetOnScrollFunc(l.onScroll)
 	return l
 }
 
@@ -82,16 +106,24 @@ func (l *List) Draw(t pixel.Target, matrix pixel.Matrix) {
 		DrawRect(t, l.DrawArea(), l.bgColor)
 	}
 	// List.
-	l.drawListItems(t)
+	l.visible = l.drawListItems(t)
+	if l.Focused() && l.highlight >= l.startIndex &&
+		l.highlight < l.startIndex+l.visible {
+		DrawRectBorder(t, l.items[l.highlight].DrawArea(), l.accentColor, 1)
+	}
 	// Buttons.
 	upButtonPos := MoveTR(l.Size(), l.upButton.Size())
 	downButtonPos := MoveBR(l.Size(), l.downButton.Size())
 	l.upButton.Draw(t, matrix.Moved(upButtonPos))
 	l.downButton.Draw(t, matrix.Moved(downButtonPos))
+	// Scrollbar.
+	l.scrollbar.SetContent(len(l.items), max(1, l.visible))
+	l.scrollbar.SetValue(l.startIndex)
+	drawScrollbar(t, matrix, l.scrollbar, l.Size(), l.upButton.Size())
 }
 
 // Update updates list.
-func (l *List) Update(win *Window) {
+func (l *List) Update(win Input) {
 	if l.Disabled() {
 		return
 	}
@@ -103,15 +135,73 @@ func (l *List) Update(win *Window) {
 			l.SetStartIndex(l.startIndex + 1)
 		}
 	}
+	// Mouse scroll.
+	if n := l.wheel.update(win, l.DrawArea()); n != 0 {
+		l.scrollbar.SetValue(l.startIndex + n)
+		l.SetStartIndex(l.scrollbar.Value())
+	}
 	// Buttons.
 	l.upButton.Update(win)
 	l.downButton.Update(win)
+	l.scrollbar.Update(win)
 	// List items.
 	for _, i := range l.items {
 		i.Update(win)
 	}
 }
 
+// HandleAction handles specified user action.
+// Up and down actions move the highlight between items,
+// accept action selects highlighted item, scroll actions
+// scroll the list.
+func (l *List) HandleAction(a Action) bool {
+	if l.Disabled() {
+		return false
+	}
+	switch a {
+	case ActionUp:
+		if l.highlight < 1 || l.highlight > len(l.items)-1 {
+			return false
+		}
+		l.SetHighlight(l.highlight - 1)
+		return true
+	case ActionDown:
+		if l.highlight >= len(l.items)-1 {
+			return false
+		}
+		l.SetHighlight(l.highlight + 1)
+		return true
+	case ActionAccept:
+		if l.highlight < 0 || l.highlight > len(l.items)-1 {
+			return false
+		}
+		l.onItemSelected(l.items[l.highlight])
+		return true
+	case ActionScrollUp:
+		l.SetStartIndex(l.startIndex - 1)
+		return true
+	case ActionScrollDown:
+		l.SetStartIndex(l.startIndex + 1)
+		return true
+	}
+	return false
+}
+
+// ApplyTheme sets colors and background from list
+// style of specified theme.
+func (l *List) ApplyTheme(t *Theme) {
+	l.bgColor = t.List.MainColor
+	l.secColor = t.List.SecColor
+	l.accentColor = t.List.AccentColor
+	l.bgSpr = t.List.Background
+	applyScrollButtonTheme(l.upButton, t, l.accentColor)
+	applyScrollButtonTheme(l.downButton, t, l.accentColor)
+	l.scrollbar.ApplyTheme(t)
+	for _, i := range l.items {
+		i.ApplyTheme(t)
+	}
+}
+
 // SetUpButtonBackground sets specified sprite as scroll up
 // button background.
 func (l *List) SetUpButtonBackground(s *pixel.Sprite) {
@@ -176,6 +266,27 @@ func (l *List) SetStartIndex(index int) {
 	}
 }
 
+// SetHighlight sets item with specified index as
+// highlighted item and scrolls list to make it visible.
+// Highlighted item is selected by accept action.
+func (l *List) SetHighlight(index int) {
+	if index < 0 || index > len(l.items)-1 {
+		return
+	}
+	l.highlight = index
+	if index < l.startIndex {
+		l.SetStartIndex(index)
+	}
+	if l.visible > 0 && index >= l.startIndex+l.visible {
+		l.SetStartIndex(index - l.visible + 1)
+	}
+}
+
+// Highlight returns index of currently highlighted item.
+func (l *List) Highlight() int {
+	return l.highlight
+}
+
 // InsertItems sets specified values with labels as
 // current list content.
 func (l *List) InsertItems(items map[string]interface{}) {
@@ -196,6 +307,7 @@ func (l *List) AddItem(label string, value interface{}) {
 // Clear clears list.
 func (l *List) Clear() {
 	l.items = nil
+	l.highlight = 0
 }
 
 // SelectedValue returns value of currently selected
@@ -210,8 +322,9 @@ func (l *List) DrawArea() pixel.Rect {
 	return l.drawArea
 }
 
-// drawListItems draws visible list content.
-func (l *List) drawListItems(t pixel.Target) {
+// drawListItems draws visible list content and returns
+// number of drawn items.
+func (l *List) drawListItems(t pixel.Target) (drawn int) {
 	if len(l.items) < 1 { // list empty
 		return
 	}
@@ -221,6 +334,7 @@ func (l *List) drawListItems(t pixel.Target) {
 		lastItemDA pixel.Rect
 	)
 	for i := l.startIndex; i < len(l.items) && contentH+lastItemDA.H() < listH; i++ {
+		drawn++
 		if i == l.startIndex { // Draw first visible item.
 			item := l.items[l.startIndex]
 			drawPos := DrawPosTC(l.DrawArea(), item.Size())
@@ -236,6 +350,7 @@ func (l *List) drawListItems(t pixel.Target) {
 		contentH += item.DrawArea().H() + ConvSize(15)
 		lastItemDA = item.DrawArea()
 	}
+	return
 }
 
 // unselectAll unselects all list items.
@@ -255,6 +370,11 @@ func (l *List) onButtonDownClicked(b *Button) {
 	l.SetStartIndex(l.startIndex + 1)
 }
 
+// Triggered after scrollbar value was changed.
+func (l *List) onScroll(s *Scrollbar) {
+	l.SetStartIndex(s.Value())
+}
+
 // Triggered after list item selected.
 func (l *List) onItemSelected(s *CheckSlot) {
 	l.unselectAll()
@@ -0,0 +1,197 @@
+/*
+ * markup.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Struct for style of text run.
+// Nil color means default color of the text.
+type textStyle struct {
+	color  color.Color
+	font   string
+	size   Size
+	bold   bool
+	italic bool
+}
+
+// Struct for text run, part of text with single
+// style.
+type textRun struct {
+	text  string
+	style textStyle
+	pause int64 // reveal pause before the run, in milliseconds
+}
+
+// Struct for opened markup tag.
+type markupTag struct {
+	name  string
+	style textStyle // style before tag
+}
+
+// EscapeMarkup escapes all markup tags in specified
+// text, so text is displayed as it is.
+func EscapeMarkup(text string) string {
+	return strings.ReplaceAll(text, "[", "[[")
+}
+
+// colorMarkup returns specified color in format of
+// color tag value, e.g. #ff0000ff.
+func colorMarkup(c color.Color) string {
+	nrgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", nrgba.R, nrgba.G, nrgba.B, nrgba.A)
+}
+
+// StripMarkup removes all markup tags from specified
+// text.
+func StripMarkup(text string) string {
+	var b strings.Builder
+	for _, r := range parseMarkup(text, textStyle{}) {
+		b.WriteString(r.text)
+	}
+	return b.String()
+}
+
+// parseMarkup parses specified text with markup tags to
+// text runs, starting with specified style.
+// Supported tags:
+// [b]bold[/b], [i]italic[/i], [color=red]color[/color],
+// [size=big]size[/size] and [font=name]font[/font].
+// Colors are parsed with ParseColor, sizes with ParseSize.
+// Pause tag without closing tag, [pause=500], sets pause
+// of text reveal in milliseconds.
+// Unknown tags are treated as a plain text, '[[' is
+// used for a literal '['.
+func parseMarkup(text string, style textStyle) []textRun {
+	var (
+		runs  []textRun
+		tags  []markupTag
+		run   strings.Builder
+		pause int64
+	)
+	addRun := func() {
+		if run.Len() > 0 {
+			runs = append(runs, textRun{run.String(), style, pause})
+			run.Reset()
+			pause = 0
+		}
+	}
+	for len(text) > 0 {
+		start := strings.IndexByte(text, '[')
+		if start < 0 {
+			run.WriteString(text)
+			break
+		}
+		run.WriteString(text[:start])
+		text = text[start:]
+		if strings.HasPrefix(text, "[[") {
+			run.WriteByte('[')
+			text = text[2:]
+			continue
+		}
+		end := strings.IndexByte(text, ']')
+		if end < 0 {
+			run.WriteString(text)
+			break
+		}
+		tag := text[1:end]
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			i := len(tags) - 1
+			for ; i >= 0 && tags[i].name != name; i-- {
+			}
+			if i < 0 {
+				run.WriteString(text[:end+1])
+				text = text[end+1:]
+				continue
+			}
+			addRun()
+			style = tags[i].style
+			tags = tags[:i]
+			text = text[end+1:]
+			continue
+		}
+		name, value, _ := strings.Cut(tag, "=")
+		if name == "pause" {
+			ms, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || ms < 0 {
+				run.WriteString(text[:end+1])
+				text = text[end+1:]
+				continue
+			}
+			addRun()
+			pause += ms
+			text = text[end+1:]
+			continue
+		}
+		newStyle, ok := applyMarkupTag(style, name, value)
+		if !ok {
+			run.WriteString(text[:end+1])
+			text = text[end+1:]
+			continue
+		}
+		addRun()
+		tags = append(tags, markupTag{name, style})
+		style = newStyle
+		text = text[end+1:]
+	}
+	addRun()
+	return runs
+}
+
+// applyMarkupTag returns specified style modified by tag
+// with specified name and value.
+// Returns false if tag is unknown or tag value is invalid.
+func applyMarkupTag(style textStyle, name, value string) (textStyle, bool) {
+	switch name {
+	case "b":
+		style.bold = true
+	case "i":
+		style.italic = true
+	case "color":
+		c, err := ParseColor(value)
+		if err != nil {
+			return style, false
+		}
+		style.color = c
+	case "size":
+		s, err := ParseSize(value)
+		if err != nil {
+			return style, false
+		}
+		style.size = s
+	case "font":
+		if len(value) < 1 {
+			return style, false
+		}
+		style.font = value
+	default:
+		return style, false
+	}
+	return style, true
+}
@@ -0,0 +1,90 @@
+/*
+ * markup_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"testing"
+
+	"golang.org/x/image/colornames"
+)
+
+// TestParseMarkup tests parsing of text with markup
+// tags.
+func TestParseMarkup(t *testing.T) {
+	runs := parseMarkup("plain [b]bold [i]both[/i][/b] [[b] [x]unknown[/x] [/b]", textStyle{})
+	expected := []textRun{
+		{text: "plain "},
+		{text: "bold ", style: textStyle{bold: true}},
+		{text: "both", style: textStyle{bold: true, italic: true}},
+		{text: " [b] [x]unknown[/x] [/b]"},
+	}
+	if len(runs) != len(expected) {
+		t.Fatalf("runs: %v, expected: %v", runs, expected)
+	}
+	for i, r := range runs {
+		if r != expected[i] {
+			t.Errorf("run %d: %v, expected: %v", i, r, expected[i])
+		}
+	}
+	runs = parseMarkup("[color=red][size=big]red[/size][/color][font=mono]mono", textStyle{})
+	if len(runs) != 2 {
+		t.Fatalf("runs: %v, expected 2 runs", runs)
+	}
+	if runs[0].style.color != colornames.Red || runs[0].style.size != SizeBig {
+		t.Errorf("color and size run style: %v", runs[0].style)
+	}
+	if runs[1].style.font != "mono" || runs[1].style.color != nil {
+		t.Errorf("font run style: %v", runs[1].style)
+	}
+}
+
+// TestParseMarkupPause tests parsing of reveal pause
+// tags.
+func TestParseMarkupPause(t *testing.T) {
+	runs := parseMarkup("a[pause=500]b[pause=x]c", textStyle{})
+	expected := []textRun{
+		{text: "a"},
+		{text: "b[pause=x]c", pause: 500},
+	}
+	if len(runs) != len(expected) {
+		t.Fatalf("runs: %v, expected: %v", runs, expected)
+	}
+	for i, r := range runs {
+		if r != expected[i] {
+			t.Errorf("run %d: %v, expected: %v", i, r, expected[i])
+		}
+	}
+}
+
+// TestStripMarkup tests removing and escaping of markup
+// tags.
+func TestStripMarkup(t *testing.T) {
+	if text := StripMarkup("[b]a[/b] [[x] [color=red]b"); text != "a [x] b" {
+		t.Errorf("stripped text: '%s', expected: 'a [x] b'", text)
+	}
+	escaped := EscapeMarkup("[b]x[/b]")
+	if text := StripMarkup(escaped); text != "[b]x[/b]" {
+		t.Errorf("escaped text: '%s', expected: '[b]x[/b]'", text)
+	}
+}
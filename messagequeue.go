@@ -51,7 +51,7 @@ func (mq *MessageQueue) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates all messages in queue.
-func (mq *MessageQueue) Update(win *Window) {
+func (mq *MessageQueue) Update(win Input) {
 	for i, m := range mq.queue {
 		if m.Opened() {
 			if i == len(mq.queue)-1 {
@@ -78,6 +78,34 @@ func (mq *MessageQueue) Remove(i int) {
 	mq.queue = append(mq.queue[:i], mq.queue[i+1:]...)
 }
 
+// DrawArea returns area covering draw areas of all
+// opened messages in the queue.
+func (mq *MessageQueue) DrawArea() (area pixel.Rect) {
+	for _, m := range mq.queue {
+		if !m.Opened() {
+			continue
+		}
+		if area.Area() == 0 {
+			area = m.DrawArea()
+			continue
+		}
+		area = area.Union(m.DrawArea())
+	}
+	return
+}
+
+// Size returns size of the biggest opened message
+// in the queue.
+func (mq *MessageQueue) Size() (size pixel.Vec) {
+	for _, m := range mq.queue {
+		if m.Opened() {
+			size.X = max(size.X, m.Size().X)
+			size.Y = max(size.Y, m.Size().Y)
+		}
+	}
+	return
+}
+
 // ContainsPosition checks whether specified position is
 // contained by any message window in the queue.
 func (mq *MessageQueue) ContainsPosition(pos pixel.Vec) bool {
@@ -26,8 +26,6 @@ package mtk
 import (
 	"image/color"
 
-	"golang.org/x/image/colornames"
-
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
 )
@@ -51,29 +49,40 @@ type MessageWindow struct {
 }
 
 // NewMessageWindow creates new message window instance.
+// Accept button label is set to text for 'accept'
+// translation key.
 func NewMessageWindow(params Params) *MessageWindow {
 	mw := new(MessageWindow)
 	mw.opened = true
 	// Background.
 	mw.size = params.Size.MessageWindowSize()
 	mw.color = params.MainColor
-	mw.colorDisable = colornames.Darkgrey
+	if mw.color == nil {
+		mw.color = theme.MessageWindow.MainColor
+	}
+	mw.colorDisable = theme.MessageWindow.DisabledColor
 	// Buttons.
+	buttonColor := params.SecColor
+	if buttonColor == nil {
+		buttonColor = theme.MessageWindow.SecColor
+	}
 	buttonParams := Params{
 		Size:      SizeSmall,
 		FontSize:  SizeSmall,
 		Shape:     ShapeRectangle,
-		MainColor: params.SecColor,
+		MainColor: buttonColor,
 	}
 	mw.acceptButton = NewButton(buttonParams)
+	mw.acceptButton.SetLabelKey("accept")
 	mw.acceptButton.SetOnClickFunc(mw.onAcceptButtonClicked)
 	// Textbox.
 	boxSize := pixel.V(mw.Size().X, mw.Size().Y-mw.acceptButton.Size().Y)
 	boxParams := Params{
 		SizeRaw:     boxSize,
 		FontSize:    params.FontSize,
-		MainColor:   params.MainColor,
-		AccentColor: params.SecColor,
+		Font:        params.Font,
+		MainColor:   mw.color,
+		AccentColor: buttonColor,
 	}
 	textbox := NewTextbox(boxParams)
 	mw.textbox = textbox
@@ -82,16 +91,23 @@ func NewMessageWindow(params Params) *MessageWindow {
 }
 
 // NewDialogWindow creates new dialog window with message.
+// Cancel button label is set to text for 'cancel'
+// translation key.
 func NewDialogWindow(params Params) *MessageWindow {
 	// Basic message window.
 	mw := NewMessageWindow(params)
 	// Buttons.
+	buttonColor := params.SecColor
+	if buttonColor == nil {
+		buttonColor = theme.MessageWindow.SecColor
+	}
 	buttonParams := Params{
 		Size:      SizeSmall,
 		Shape:     ShapeRectangle,
-		MainColor: params.SecColor,
+		MainColor: buttonColor,
 	}
 	mw.cancelButton = NewButton(buttonParams)
+	mw.cancelButton.SetLabelKey("cancel")
 	mw.cancelButton.SetOnClickFunc(mw.onCancelButtonClicked)
 	return mw
 }
@@ -119,7 +135,7 @@ func (mw *MessageWindow) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update handles key press events.
-func (mw *MessageWindow) Update(win *Window) {
+func (mw *MessageWindow) Update(win Input) {
 	if mw.Disabled() {
 		return
 	}
@@ -144,6 +160,38 @@ func (mw *MessageWindow) Update(win *Window) {
 	}
 }
 
+// HandleAction handles specified user action.
+// Accept action accepts message, cancel action
+// cancels message.
+func (mw *MessageWindow) HandleAction(a Action) bool {
+	if mw.Disabled() {
+		return false
+	}
+	switch a {
+	case ActionAccept:
+		mw.accept()
+		return true
+	case ActionCancel:
+		mw.cancel()
+		return true
+	}
+	return false
+}
+
+// ApplyTheme sets colors from message window style of
+// specified theme.
+func (mw *MessageWindow) ApplyTheme(t *Theme) {
+	mw.color = t.MessageWindow.MainColor
+	mw.colorDisable = t.MessageWindow.DisabledColor
+	mw.textbox.ApplyTheme(t)
+	mw.acceptButton.ApplyTheme(t)
+	mw.acceptButton.SetColor(t.MessageWindow.SecColor)
+	if mw.cancelButton != nil {
+		mw.cancelButton.ApplyTheme(t)
+		mw.cancelButton.SetColor(t.MessageWindow.SecColor)
+	}
+}
+
 // Show toggles window visibility.
 func (mw *MessageWindow) Show(show bool) {
 	mw.opened = show
@@ -207,6 +255,29 @@ func (mw *MessageWindow) SetCancelLabel(l string) {
 	mw.cancelButton.SetLabel(l)
 }
 
+// SetAcceptLabelKey sets text for specified translation key,
+// formatted with specified arguments, as label for accept
+// button.
+func (mw *MessageWindow) SetAcceptLabelKey(key string, args ...interface{}) {
+	mw.acceptButton.SetLabelKey(key, args...)
+}
+
+// SetCancelLabelKey sets text for specified translation key,
+// formatted with specified arguments, as label for cancel
+// button.
+func (mw *MessageWindow) SetCancelLabelKey(key string, args ...interface{}) {
+	if mw.cancelButton == nil {
+		return
+	}
+	mw.cancelButton.SetLabelKey(key, args...)
+}
+
+// SetTextKey sets text for specified translation key,
+// formatted with specified arguments, as window message.
+func (mw *MessageWindow) SetTextKey(key string, args ...interface{}) {
+	mw.textbox.SetTextKey(key, args...)
+}
+
 // SetOnAcceptFunc sets specified function as function triggered after
 // message was accepted.
 func (mw *MessageWindow) SetOnAcceptFunc(f func(msg *MessageWindow)) {
@@ -39,6 +39,7 @@ import (
 
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/imdraw"
+	"github.com/gopxl/pixel/pixelgl"
 	"github.com/gopxl/pixel/text"
 )
 
@@ -52,10 +53,73 @@ const (
 	// Shapes.
 	ShapeRectangle Shape = iota
 	ShapeSquare
-	// Aligns
+	// Fonts.
+	MainFontName = "main"
+)
+
+// Aligns.
+const (
 	AlignCenter Align = iota
 	AlignRight
 	AlignLeft
+	AlignTop
+	AlignBottom
+)
+
+// Anchors.
+const (
+	AnchorCenter Anchor = iota
+	AnchorTop
+	AnchorTopRight
+	AnchorRight
+	AnchorBottomRight
+	AnchorBottom
+	AnchorBottomLeft
+	AnchorLeft
+	AnchorTopLeft
+)
+
+// Stretches.
+const (
+	StretchNone Stretch = iota
+	StretchHorizontal
+	StretchVertical
+	StretchBoth
+)
+
+// Actions.
+const (
+	ActionUp Action = iota
+	ActionDown
+	ActionLeft
+	ActionRight
+	ActionAccept
+	ActionCancel
+	ActionScrollUp
+	ActionScrollDown
+)
+
+// Wraps.
+const (
+	WrapWord Wrap = iota
+	WrapChar
+	WrapNone
+)
+
+// Overflows.
+const (
+	OverflowWrap Overflow = iota
+	OverflowEllipsis
+	OverflowClip
+	OverflowShrink
+	OverflowMarquee
+)
+
+// Gradients.
+const (
+	GradientNone Gradient = iota
+	GradientHorizontal
+	GradientVertical
 )
 
 var (
@@ -63,13 +127,27 @@ var (
 	// like button click sound for example.
 	audio            *AudioPlayer = NewAudioPlayer()
 	buttonClickSound *beep.Buffer
+	// Theme.
+	theme *Theme = DefaultTheme()
+	// Localization.
+	fallbackLanguage string              = "en"
+	language         string              = fallbackLanguage
+	langRevision     int                 // increased on each language change
+	catalogs         map[string]*Catalog = map[string]*Catalog{
+		fallbackLanguage: defaultCatalog(),
+	}
+	loadFuzzy bool // load fuzzy PO translations
 	// Font.
-	fallbackFont font.Face = basicfont.Face7x13
-	mainFontBase *truetype.Font
+	fallbackFont font.Face             = basicfont.Face7x13
+	fonts        map[string]*fontEntry = make(map[string]*fontEntry)
+	runeSets     [][]rune              = [][]rune{text.ASCII}
 	// Time.
 	secTimer = time.Tick(time.Second)
 	// Draw for shape drawing functions.
 	draw = imdraw.New(nil)
+	// Canvas and sprite for clipped texts.
+	clipCanvas *pixelgl.Canvas
+	clipSprite *pixel.Sprite
 )
 
 // Type for shapes of UI elements.
@@ -81,9 +159,75 @@ type Shape int
 type Size int
 
 // Type for aligns.
-// Directions: center(0), right(1), left(2)
+// Directions: center(0), right(1), left(2), top(3), bottom(4).
+// Center align is the default align.
 type Align int
 
+// Type for anchors of UI elements in anchor layout.
+// Anchors: center(0), top(1), top right(2), right(3),
+// bottom right(4), bottom(5), bottom left(6), left(7),
+// top left(8).
+type Anchor int
+
+// Type for stretches of UI elements in anchor layout.
+// Stretches: none(0), horizontal(1), vertical(2), both(3).
+type Stretch int
+
+// Type for user actions, triggered by gamepad buttons.
+// Actions: up(0), down(1), left(2), right(3), accept(4),
+// cancel(5), scroll up(6), scroll down(7).
+type Action int
+
+// Type for text wrap modes.
+// Modes: word(0), char(1), none(2).
+// Word wrap is the default mode.
+type Wrap int
+
+// Type for text overflow modes.
+// Modes: wrap(0), ellipsis(1), clip(2), shrink(3),
+// marquee(4).
+// Wrap is the default mode.
+type Overflow int
+
+// Type for directions of text color gradients.
+// Directions: none(0), horizontal(1), vertical(2).
+type Gradient int
+
+// Interface for all graphical UI elements, like buttons,
+// switches, lists, etc.
+type Widget interface {
+	Draw(t pixel.Target, matrix pixel.Matrix)
+	Update(win Input)
+	DrawArea() pixel.Rect
+	Size() pixel.Vec
+}
+
+// Widget implementations.
+var (
+	_ Widget = (*AnchorLayout)(nil)
+	_ Widget = (*Animation)(nil)
+	_ Widget = (*Box)(nil)
+	_ Widget = (*Button)(nil)
+	_ Widget = (*CheckSlot)(nil)
+	_ Widget = (*Grid)(nil)
+	_ Widget = (*Layout)(nil)
+	_ Widget = (*List)(nil)
+	_ Widget = (*MessageQueue)(nil)
+	_ Widget = (*MessageWindow)(nil)
+	_ Widget = (*MultiAnimation)(nil)
+	_ Widget = (*Panel)(nil)
+	_ Widget = (*ProgressBar)(nil)
+	_ Widget = (*ScrollPane)(nil)
+	_ Widget = (*Scrollbar)(nil)
+	_ Widget = (*Slot)(nil)
+	_ Widget = (*SlotList)(nil)
+	_ Widget = (*Switch)(nil)
+	_ Widget = (*Text)(nil)
+	_ Widget = (*TextArea)(nil)
+	_ Widget = (*Textbox)(nil)
+	_ Widget = (*Textedit)(nil)
+)
+
 // Interface for all 'focusable' UI elements, like buttons,
 // switches, etc.
 type Focuser interface {
@@ -91,6 +235,35 @@ type Focuser interface {
 	Focused() bool
 }
 
+// Interface for user input sources, like MTK window.
+// All UI elements read mouse, keyboard and time state
+// from input source during update.
+type Input interface {
+	Pressed(button pixelgl.Button) bool
+	JustPressed(button pixelgl.Button) bool
+	JustReleased(button pixelgl.Button) bool
+	Repeated(button pixelgl.Button) bool
+	MousePosition() pixel.Vec
+	MouseScroll() pixel.Vec
+	Typed() string
+	Delta() int64
+}
+
+// Interface for input sources with access to the system
+// clipboard, like MTK window.
+type ClipboardInput interface {
+	Clipboard() string
+	SetClipboard(s string)
+}
+
+// Interface for UI elements that handle user actions,
+// like gamepad buttons.
+// HandleAction should return true if action was handled
+// by the element.
+type ActionHandler interface {
+	HandleAction(a Action) bool
+}
+
 // Focus represents user focus on UI element.
 type Focus struct {
 	element Focuser
@@ -110,6 +283,11 @@ func (f *Focus) Focus(e Focuser) {
 	f.element.Focus(true)
 }
 
+// Element returns currently focused element.
+func (f *Focus) Element() Focuser {
+	return f.element
+}
+
 // ButtonSize returns szie parameters for button with
 // this size and with specifed shape.
 func (s Size) ButtonSize(sh Shape) pixel.Vec {
@@ -216,7 +394,17 @@ func Audio() *AudioPlayer {
 // Sets specified truetype font as current main font of
 // the interface.
 func SetMainFont(font *truetype.Font) {
-	mainFontBase = font
+	RegisterFont(MainFontName, font)
+}
+
+// SetRuneSets sets specified rune sets as sets of runes
+// included in all new text atlases, e.g. text.ASCII or
+// text.RangeTable(unicode.Latin).
+// Runes outside of these sets are added to atlases when
+// they are encountered for the first time.
+func SetRuneSets(sets ...[]rune) {
+	runeSets = sets
+	clearFontCache()
 }
 
 // SetButtonClickSound sets specified audio buffer
@@ -225,27 +413,23 @@ func SetButtonClickSound(s *beep.Buffer) {
 	buttonClickSound = s
 }
 
-// MainFont returns main font in specified size from
-// data package.
+// MainFont returns main font in specified size.
 func MainFont(s Size) font.Face {
-	switch {
-	case s <= SizeMini:
-		return createMainFont(10)
-	case s == SizeSmall:
-		return createMainFont(15)
-	case s == SizeMedium:
-		return createMainFont(20)
-	case s >= SizeBig:
-		return createMainFont(30)
-	default:
-		return createMainFont(10)
-	}
+	return Font(MainFontName, s)
 }
 
 // Atlas returns atlas for UI text with specified
 // font.
 func Atlas(f *font.Face) *text.Atlas {
-	return text.NewAtlas(*f, text.ASCII)
+	return text.NewAtlas(*f, runeSets...)
+}
+
+// MainAtlas returns dynamic atlas for main font in
+// specified size.
+// Atlas is shared by all UI texts with the same font
+// size.
+func MainAtlas(s Size) *DynamicAtlas {
+	return FontAtlas(MainFontName, s)
 }
 
 // Matrix return scaled identity matrix.
@@ -264,14 +448,14 @@ func DrawRect(t pixel.Target, drawArea pixel.Rect, color color.Color) {
 	draw.Draw(t)
 }
 
-// createMainFont creates new main font face with
-// specified size.
-func createMainFont(size float64) font.Face {
-	if mainFontBase == nil {
-		return fallbackFont
-	}
-	return truetype.NewFace(mainFontBase, &truetype.Options{
-		Size:              size,
-		GlyphCacheEntries: 1,
-	})
+// DrawRectBorder draws rectangle border on specified
+// target with specified draw area, color and thickness.
+func DrawRectBorder(t pixel.Target, drawArea pixel.Rect, color color.Color,
+	thickness float64) {
+	draw.Clear()
+	draw.Color = color
+	draw.Push(drawArea.Min)
+	draw.Push(drawArea.Max)
+	draw.Rectangle(thickness)
+	draw.Draw(t)
 }
@@ -62,7 +62,7 @@ func (ma *MultiAnimation) DrawColorMask(t pixel.Target, matrix pixel.Matrix, mas
 }
 
 // Update updates animation for current direction.
-func (ma *MultiAnimation) Update(win *Window) {
+func (ma *MultiAnimation) Update(win Input) {
 	ma.upAnim.Update(win)
 	ma.rightAnim.Update(win)
 	ma.downAnim.Update(win)
@@ -94,6 +94,11 @@ func (ma *MultiAnimation) DrawArea() pixel.Rect {
 	return ma.drawAnim.DrawArea()
 }
 
+// Size returns size of current animation frame.
+func (ma *MultiAnimation) Size() pixel.Vec {
+	return ma.drawAnim.Size()
+}
+
 // Finished checks whether current animation
 // is finished.
 func (ma *MultiAnimation) Finished() bool {
@@ -0,0 +1,190 @@
+/*
+ * panel.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for panels, containers for other UI elements.
+// Panel draws and updates all its children in order
+// of adding.
+type Panel struct {
+	bgSpr    *pixel.Sprite
+	size     pixel.Vec
+	color    color.Color
+	drawArea pixel.Rect // updated on each draw
+	children []*panelChild
+}
+
+// Struct for panel child element with offset
+// from the panel center.
+type panelChild struct {
+	widget Widget
+	offset pixel.Vec
+}
+
+// NewPanel creates new panel with specified parameters.
+// If raw size is not specified then panel size is
+// calculated from sizes and offsets of its children.
+func NewPanel(params Params) *Panel {
+	p := new(Panel)
+	p.size = params.SizeRaw
+	p.color = params.MainColor
+	if p.color == nil {
+		p.color = theme.Panel.MainColor
+	}
+	p.bgSpr = params.Background
+	if p.bgSpr == nil {
+		p.bgSpr = theme.Panel.Background
+	}
+	return p
+}
+
+// Draw draws panel background and all panel children.
+func (p *Panel) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Calculating draw area.
+	p.drawArea = MatrixToDrawArea(matrix, p.Size())
+	// Background.
+	if p.bgSpr != nil {
+		p.bgSpr.Draw(t, matrix)
+	} else if p.color != nil {
+		DrawRect(t, p.DrawArea(), p.color)
+	}
+	// Children.
+	for _, c := range p.children {
+		c.widget.Draw(t, matrix.Moved(c.offset))
+	}
+}
+
+// Update updates all panel children.
+func (p *Panel) Update(win Input) {
+	for _, c := range p.children {
+		c.widget.Update(win)
+	}
+}
+
+// Add adds specified element to the panel, with
+// specified offset from the panel center.
+func (p *Panel) Add(w Widget, offset pixel.Vec) {
+	p.children = append(p.children, &panelChild{w, offset})
+}
+
+// Remove removes specified element from the panel.
+func (p *Panel) Remove(w Widget) {
+	for i, c := range p.children {
+		if c.widget == w {
+			p.children = append(p.children[:i], p.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Clear removes all elements from the panel.
+func (p *Panel) Clear() {
+	p.children = nil
+}
+
+// Children returns all panel elements.
+func (p *Panel) Children() []Widget {
+	children := make([]Widget, len(p.children))
+	for i, c := range p.children {
+		children[i] = c.widget
+	}
+	return children
+}
+
+// Offset returns offset of specified element from
+// the panel center.
+func (p *Panel) Offset(w Widget) pixel.Vec {
+	for _, c := range p.children {
+		if c.widget == w {
+			return c.offset
+		}
+	}
+	return pixel.ZV
+}
+
+// SetOffset sets specified vector as offset of
+// specified element from the panel center.
+func (p *Panel) SetOffset(w Widget, offset pixel.Vec) {
+	for _, c := range p.children {
+		if c.widget == w {
+			c.offset = offset
+			return
+		}
+	}
+}
+
+// ApplyTheme sets color and background from panel style
+// of specified theme.
+// Theme is also applied to all panel elements.
+func (p *Panel) ApplyTheme(t *Theme) {
+	p.color = t.Panel.MainColor
+	p.bgSpr = t.Panel.Background
+	applyChildrenTheme(p.Children(), t)
+}
+
+// SetBackground sets specified sprite as panel
+// background, also removes background color.
+func (p *Panel) SetBackground(s *pixel.Sprite) {
+	p.bgSpr = s
+	p.color = nil
+}
+
+// SetColor sets specified color as panel
+// background color.
+func (p *Panel) SetColor(c color.Color) {
+	p.color = c
+}
+
+// SetSize sets panel size.
+func (p *Panel) SetSize(s pixel.Vec) {
+	p.size = s
+}
+
+// Size returns panel size.
+func (p *Panel) Size() pixel.Vec {
+	if p.bgSpr != nil {
+		return p.bgSpr.Frame().Size()
+	}
+	if p.size != pixel.ZV {
+		return p.size
+	}
+	var size pixel.Vec
+	for _, c := range p.children {
+		childSize := c.widget.Size()
+		size.X = math.Max(size.X, (math.Abs(c.offset.X)+childSize.X/2)*2)
+		size.Y = math.Max(size.Y, (math.Abs(c.offset.Y)+childSize.Y/2)*2)
+	}
+	return size
+}
+
+// DrawArea returns current panel draw area.
+func (p *Panel) DrawArea() pixel.Rect {
+	return p.drawArea
+}
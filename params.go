@@ -37,7 +37,11 @@ type Params struct {
 	Size        Size
 	SizeRaw     pixel.Vec
 	FontSize    Size
+	Font        string
 	Shape       Shape
+	Wrap        Wrap
+	Overflow    Overflow
+	TextEffects TextEffects
 	Background  *pixel.Sprite
 	Label       string
 	Info        string
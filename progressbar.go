@@ -50,6 +50,9 @@ func NewProgressBar(size Size, color color.Color) *ProgressBar {
 	pb := new(ProgressBar)
 	pb.size = size.BarSize()
 	pb.color = color
+	if pb.color == nil {
+		pb.color = theme.ProgressBar.MainColor
+	}
 	pb.maxSize = size.BarSize()
 	labelParams := Params{
 		FontSize: size - 1,
@@ -77,7 +80,7 @@ func (pb *ProgressBar) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates progress bar.
-func (pb *ProgressBar) Update(win *Window) {
+func (pb *ProgressBar) Update(win Input) {
 	// On-hover.
 	if pb.DrawArea().Contains(win.MousePosition()) {
 		pb.hovered = true
@@ -86,6 +89,15 @@ func (pb *ProgressBar) Update(win *Window) {
 	}
 }
 
+// ApplyTheme sets color from progress bar style of
+// specified theme.
+func (pb *ProgressBar) ApplyTheme(t *Theme) {
+	if pb.bgSpr == nil {
+		pb.color = t.ProgressBar.MainColor
+	}
+	pb.label.ApplyTheme(t)
+}
+
 // SetBackground sets specified sprite as bar
 // background, also removes current background color.
 func (pb *ProgressBar) SetBackground(p pixel.Picture) {
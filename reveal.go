@@ -0,0 +1,136 @@
+/*
+ * reveal.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"github.com/gopxl/beep"
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for typewriter reveal of text, character
+// by character.
+type textReveal struct {
+	speed    float64 // characters per second
+	shown    float64 // number of revealed characters
+	total    int
+	wait     float64          // remaining pause in milliseconds
+	pauses   map[int]int64    // pauses before characters with specified indices
+	sound    *beep.Buffer     // played for revealed characters
+	skipKeys []pixelgl.Button // keys that reveal whole text
+	active   bool
+}
+
+// newTextReveal creates new text reveal with default
+// skip keys.
+func newTextReveal() textReveal {
+	return textReveal{
+		skipKeys: []pixelgl.Button{pixelgl.KeySpace, pixelgl.KeyEnter},
+	}
+}
+
+// start starts revealing of text with specified number of
+// characters and reveal pauses.
+func (r *textReveal) start(total int, pauses map[int]int64) {
+	r.shown = 0
+	r.total = total
+	r.pauses = pauses
+	r.wait = float64(pauses[0])
+	r.active = total > 0
+}
+
+// update reveals characters for specified time in
+// milliseconds, and plays reveal sound if any character
+// was revealed.
+// Sound is played once per update, even if many characters
+// were revealed, so sounds do not stack up with high
+// reveal speed.
+// Returns true if number of revealed characters was
+// changed.
+func (r *textReveal) update(delta int64) bool {
+	if !r.active {
+		return false
+	}
+	prev := int(r.shown)
+	time := float64(delta)
+	for time > 0 && int(r.shown) < r.total {
+		if r.wait > 0 {
+			spent := min(r.wait, time)
+			r.wait -= spent
+			time -= spent
+			continue
+		}
+		next := int(r.shown) + 1
+		step := (float64(next) - r.shown) * 1000 / r.speed
+		if time < step {
+			r.shown += time * r.speed / 1000
+			break
+		}
+		time -= step
+		r.shown = float64(next)
+		r.wait = float64(r.pauses[next])
+	}
+	if int(r.shown) >= r.total {
+		r.active = false
+	}
+	if int(r.shown) == prev {
+		return !r.active
+	}
+	if audio != nil && r.sound != nil {
+		audio.Play(r.sound)
+	}
+	return true
+}
+
+// updateInput reveals characters for time from last
+// update of specified input, or reveals whole text after
+// click in specified area or skip key press.
+// Skip keys work only if specified area is under mouse
+// cursor or text element is focused.
+// Returns true if number of revealed characters was
+// changed.
+func (r *textReveal) updateInput(win Input, area pixel.Rect, focused bool) bool {
+	if !r.active {
+		return false
+	}
+	hovered := area.Contains(win.MousePosition())
+	if win.JustPressed(pixelgl.MouseButtonLeft) && hovered {
+		r.skip()
+		return true
+	}
+	for _, k := range r.skipKeys {
+		if (focused || hovered) && win.JustPressed(k) {
+			r.skip()
+			return true
+		}
+	}
+	return r.update(win.Delta())
+}
+
+// skip reveals all characters.
+func (r *textReveal) skip() {
+	r.shown = float64(r.total)
+	r.wait = 0
+	r.active = false
+}
@@ -0,0 +1,296 @@
+/*
+ * scrollbar.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for vertical and horizontal scrollbars.
+// Scrollbar shows position of visible part of scrolled
+// content with thumb proportional to the visible part.
+// Content can be scrolled by dragging the thumb, or by
+// clicking the track before or after the thumb to scroll
+// by one page.
+type Scrollbar struct {
+	size       pixel.Vec
+	color      color.Color
+	thumbColor color.Color
+	hoverColor color.Color
+	drawArea   pixel.Rect // updated on each draw
+	total      int        // number of content units, e.g. lines
+	visible    int        // number of visible content units
+	value      int        // first visible content unit
+	dragging   bool
+	dragOffset float64 // mouse offset from the thumb start
+	hovered    bool
+	horizontal bool
+	onScroll   func(s *Scrollbar)
+}
+
+const (
+	// Minimal scrollbar thumb length for 1080p.
+	minThumbLength = 10
+)
+
+// NewScrollbar creates new vertical scrollbar with
+// specified parameters.
+// Main color is used for the track, accent color for
+// the thumb.
+func NewScrollbar(params Params) *Scrollbar {
+	return newScrollbar(params)
+}
+
+// NewHScrollbar creates new horizontal scrollbar with
+// specified parameters.
+// Main color is used for the track, accent color for
+// the thumb.
+func NewHScrollbar(params Params) *Scrollbar {
+	s := newScrollbar(params)
+	s.horizontal = true
+	return s
+}
+
+// newScrollbar creates new scrollbar with specified
+// parameters.
+func newScrollbar(params Params) *Scrollbar {
+	s := new(Scrollbar)
+	s.size = params.SizeRaw
+	s.color = params.MainColor
+	if s.color == nil {
+		s.color = theme.Scrollbar.MainColor
+	}
+	s.thumbColor = params.AccentColor
+	if s.thumbColor == nil {
+		s.thumbColor = theme.Scrollbar.AccentColor
+	}
+	s.hoverColor = theme.Scrollbar.HoverColor
+	return s
+}
+
+// Draw draws scrollbar track and thumb.
+func (s *Scrollbar) Draw(t pixel.Target, matrix pixel.Matrix) {
+	s.drawArea = MatrixToDrawArea(matrix, s.Size())
+	if s.color != nil {
+		DrawRect(t, s.DrawArea(), s.color)
+	}
+	color := s.thumbColor
+	if (s.hovered || s.dragging) && s.hoverColor != nil {
+		color = s.hoverColor
+	}
+	if color != nil {
+		DrawRect(t, s.thumbArea(), color)
+	}
+}
+
+// Update handles thumb dragging and track clicks.
+func (s *Scrollbar) Update(win Input) {
+	mousePos := win.MousePosition()
+	thumb := s.thumbArea()
+	s.hovered = thumb.Contains(mousePos)
+	if win.JustPressed(pixelgl.MouseButtonLeft) && s.DrawArea().Contains(mousePos) {
+		switch {
+		case s.hovered:
+			s.dragging = true
+			s.dragOffset = s.axisPos(mousePos) - s.axisPos(s.areaStart(thumb))
+		case s.axisPos(mousePos) < s.axisPos(s.areaStart(thumb)):
+			s.scroll(s.value - s.visible)
+		default:
+			s.scroll(s.value + s.visible)
+		}
+	}
+	if s.dragging && win.Pressed(pixelgl.MouseButtonLeft) {
+		track := s.length(s.DrawArea()) - s.length(thumb)
+		if track > 0 {
+			start := s.axisPos(s.areaStart(s.DrawArea()))
+			pos := (s.axisPos(mousePos) - s.dragOffset - start) / track
+			s.scroll(int(math.Round(pos * float64(s.maxValue()))))
+		}
+	}
+	if win.JustReleased(pixelgl.MouseButtonLeft) {
+		s.dragging = false
+	}
+}
+
+// ApplyTheme sets colors from scrollbar style of
+// specified theme.
+func (s *Scrollbar) ApplyTheme(t *Theme) {
+	s.color = t.Scrollbar.MainColor
+	s.thumbColor = t.Scrollbar.AccentColor
+	s.hoverColor = t.Scrollbar.HoverColor
+}
+
+// SetContent sets specified numbers of all and visible
+// content units, e.g. lines or items.
+// Current value is limited to the new content.
+func (s *Scrollbar) SetContent(total, visible int) {
+	s.total = total
+	s.visible = visible
+	s.SetValue(s.value)
+}
+
+// SetValue sets specified content unit as the first
+// visible unit.
+// Value is limited to the range from 0 to the number of
+// units that are not visible.
+func (s *Scrollbar) SetValue(value int) {
+	s.value = max(0, min(value, s.maxValue()))
+}
+
+// Value returns first visible content unit.
+func (s *Scrollbar) Value() int {
+	return s.value
+}
+
+// Dragging checks if scrollbar thumb is dragged.
+func (s *Scrollbar) Dragging() bool {
+	return s.dragging
+}
+
+// SetOnScrollFunc sets specified function as function
+// triggered after scrollbar value was changed by user.
+func (s *Scrollbar) SetOnScrollFunc(f func(s *Scrollbar)) {
+	s.onScroll = f
+}
+
+// SetColor sets specified color as track color.
+func (s *Scrollbar) SetColor(c color.Color) {
+	s.color = c
+}
+
+// SetThumbColor sets specified color as thumb color.
+func (s *Scrollbar) SetThumbColor(c color.Color) {
+	s.thumbColor = c
+}
+
+// SetSize sets scrollbar size.
+func (s *Scrollbar) SetSize(size pixel.Vec) {
+	s.size = size
+}
+
+// Size returns scrollbar size.
+func (s *Scrollbar) Size() pixel.Vec {
+	return s.size
+}
+
+// DrawArea returns current scrollbar draw area.
+func (s *Scrollbar) DrawArea() pixel.Rect {
+	return s.drawArea
+}
+
+// scroll sets specified value and triggers on-scroll
+// function if value was changed.
+func (s *Scrollbar) scroll(value int) {
+	prev := s.value
+	s.SetValue(value)
+	if s.value != prev && s.onScroll != nil {
+		s.onScroll(s)
+	}
+}
+
+// maxValue returns maximal scrollbar value.
+func (s *Scrollbar) maxValue() int {
+	return max(0, s.total-s.visible)
+}
+
+// thumbArea returns current thumb draw area.
+// Thumb fills whole track if all content is visible.
+func (s *Scrollbar) thumbArea() pixel.Rect {
+	area := s.DrawArea()
+	if s.total <= s.visible || s.total < 1 {
+		return area
+	}
+	trackLength := s.length(area)
+	length := trackLength * float64(s.visible) / float64(s.total)
+	length = math.Min(trackLength, math.Max(length, ConvSize(minThumbLength)))
+	move := (trackLength - length) * float64(s.value) / float64(s.maxValue())
+	if s.horizontal {
+		left := area.Min.X + move
+		return pixel.R(left, area.Min.Y, left+length, area.Max.Y)
+	}
+	top := area.Max.Y - move
+	return pixel.R(area.Min.X, top-length, area.Max.X, top)
+}
+
+// areaStart returns point where specified area starts
+// along the scrollbar axis, top for vertical scrollbar and
+// left for horizontal one.
+func (s *Scrollbar) areaStart(area pixel.Rect) pixel.Vec {
+	if s.horizontal {
+		return area.Min
+	}
+	return pixel.V(area.Min.X, area.Max.Y)
+}
+
+// axisPos returns position of specified point along the
+// scrollbar axis, increasing in the scroll direction.
+func (s *Scrollbar) axisPos(pos pixel.Vec) float64 {
+	if s.horizontal {
+		return pos.X
+	}
+	return -pos.Y
+}
+
+// length returns length of specified area along the
+// scrollbar axis.
+func (s *Scrollbar) length(area pixel.Rect) float64 {
+	if s.horizontal {
+		return area.W()
+	}
+	return area.H()
+}
+
+// drawScrollbar draws specified scrollbar at the right edge
+// of background with specified size, between scroll buttons
+// with specified size.
+func drawScrollbar(t pixel.Target, matrix pixel.Matrix, s *Scrollbar, bgSize, buttonSize pixel.Vec) {
+	s.SetSize(pixel.V(buttonSize.X, math.Max(0, bgSize.Y-buttonSize.Y*2)))
+	s.Draw(t, matrix.Moved(pixel.V(bgSize.X/2-buttonSize.X/2, 0)))
+}
+
+// Struct for mouse wheel scroll of content, that
+// accumulates fractional scroll, e.g. from trackpads,
+// between frames.
+type wheelScroll struct {
+	rest float64 // scroll not applied to content yet
+}
+
+// update returns number of content units to scroll by
+// mouse wheel in the current frame, if mouse is over
+// specified area.
+// Returns negative number for scrolling up.
+func (ws *wheelScroll) update(win Input, area pixel.Rect) int {
+	if !area.Contains(win.MousePosition()) {
+		ws.rest = 0
+		return 0
+	}
+	ws.rest -= win.MouseScroll().Y
+	n := int(ws.rest)
+	ws.rest -= float64(n)
+	return n
+}
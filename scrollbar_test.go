@@ -0,0 +1,99 @@
+/*
+ * scrollbar_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"testing"
+
+	"github.com/gopxl/pixel"
+)
+
+// TestScrollbarValue tests limiting scrollbar value to
+// the scrollbar content.
+func TestScrollbarValue(t *testing.T) {
+	s := NewScrollbar(Params{SizeRaw: pixel.V(10, 100)})
+	s.SetContent(10, 4)
+	tests := []struct {
+		value, expected int
+	}{
+		{3, 3},
+		{10, 6},
+		{-1, 0},
+	}
+	for _, test := range tests {
+		s.SetValue(test.value)
+		if s.Value() != test.expected {
+			t.Errorf("value after setting %d: %d, expected: %d", test.value, s.Value(), test.expected)
+		}
+	}
+	s.SetValue(6)
+	s.SetContent(8, 4)
+	if s.Value() != 4 {
+		t.Errorf("value after content change: %d, expected: 4", s.Value())
+	}
+	s.SetContent(3, 4)
+	if s.Value() != 0 {
+		t.Errorf("value with all content visible: %d, expected: 0", s.Value())
+	}
+}
+
+// TestWheelScroll tests accumulating fractional mouse
+// wheel scroll.
+func TestWheelScroll(t *testing.T) {
+	area := pixel.R(0, 0, 100, 100)
+	in := NewVirtualInput()
+	in.SetMousePosition(pixel.V(50, 50))
+	var ws wheelScroll
+	tests := []struct {
+		scroll float64
+		n      int
+	}{
+		{0.4, 0},
+		{0.4, 0},
+		{0.4, -1},
+		{-0.3, 0},
+		{-3, 3},
+		{2, -1},
+	}
+	for i, test := range tests {
+		in.Update(16)
+		in.Scroll(pixel.V(0, test.scroll))
+		if n := ws.update(in, area); n != test.n {
+			t.Errorf("scroll %d: %d, expected: %d", i, n, test.n)
+		}
+	}
+	// Scroll is reset when mouse leaves the area.
+	in.Update(16)
+	in.SetMousePosition(pixel.V(150, 50))
+	in.Scroll(pixel.V(0, 1))
+	if n := ws.update(in, area); n != 0 {
+		t.Errorf("scroll outside area: %d, expected: 0", n)
+	}
+	in.Update(16)
+	in.SetMousePosition(pixel.V(50, 50))
+	in.Scroll(pixel.V(0, 0.5))
+	if n := ws.update(in, area); n != 0 {
+		t.Errorf("scroll after reset: %d, expected: 0", n)
+	}
+}
@@ -0,0 +1,316 @@
+/*
+ * scrollpane.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for scroll panes, containers that display part
+// of content widget bigger than the pane.
+// Content is clipped to the pane draw area, and can be
+// scrolled vertically with mouse wheel, horizontally with
+// Shift and mouse wheel, or with scrollbars.
+// Mouse wheel over scrollable content, like lists or text
+// boxes, scrolls the content instead of the pane.
+// Content receives input with mouse position relative to
+// the pane canvas, mouse position outside the pane is not
+// passed to the content, so clipped parts of the content
+// can not be clicked.
+type ScrollPane struct {
+	size       pixel.Vec
+	color      color.Color
+	drawArea   pixel.Rect // updated on each draw
+	canvas     *pixelgl.Canvas
+	content    Widget
+	offset     pixel.Vec // content scroll, in pixels from the top left corner
+	vScrollbar *Scrollbar
+	hScrollbar *Scrollbar
+	input      *paneInput
+	scrollStep float64
+}
+
+// Struct for input source of scroll pane content, that
+// translates mouse position to the pane canvas.
+type paneInput struct {
+	Input
+	area     pixel.Rect // visible area of the pane
+	scrolled bool       // mouse scroll was used by the content
+}
+
+const (
+	// Scrollbar width for 1080p.
+	scrollbarWidth = 10
+)
+
+// NewScrollPane creates new scroll pane with specified
+// parameters.
+func NewScrollPane(params Params) *ScrollPane {
+	sp := new(ScrollPane)
+	sp.size = params.SizeRaw
+	sp.color = params.MainColor
+	if sp.color == nil {
+		sp.color = theme.Panel.MainColor
+	}
+	sp.scrollStep = 30
+	sp.input = new(paneInput)
+	// Scrollbars.
+	scrollbarParams := Params{AccentColor: params.AccentColor}
+	sp.vScrollbar = NewScrollbar(scrollbarParams)
+	sp.vScrollbar.SetOnScrollFunc(sp.onScroll)
+	sp.hScrollbar = NewHScrollbar(scrollbarParams)
+	sp.hScrollbar.SetOnScrollFunc(sp.onScroll)
+	return sp
+}
+
+// Draw draws scroll pane background, visible part of
+// the content and scrollbars.
+func (sp *ScrollPane) Draw(t pixel.Target, matrix pixel.Matrix) {
+	sp.drawArea = MatrixToDrawArea(matrix, sp.Size())
+	sp.SetOffset(sp.offset)
+	if sp.color != nil {
+		DrawRect(t, sp.DrawArea(), sp.color)
+	}
+	if sp.content == nil {
+		return
+	}
+	// Content.
+	view := sp.viewArea()
+	if view.Area() <= 0 {
+		return
+	}
+	canvasBounds := pixel.R(0, 0, view.W(), view.H())
+	if sp.canvas == nil {
+		sp.canvas = pixelgl.NewCanvas(canvasBounds)
+	}
+	if sp.canvas.Bounds() != canvasBounds {
+		sp.canvas.SetBounds(canvasBounds)
+	}
+	sp.canvas.Clear(pixel.Alpha(0))
+	contentSize := sp.content.Size()
+	contentPos := pixel.V(contentSize.X/2-sp.offset.X, view.H()-contentSize.Y/2+sp.offset.Y)
+	sp.content.Draw(sp.canvas, Matrix().Moved(contentPos))
+	sp.canvas.Draw(t, pixel.IM.Moved(view.Center()))
+	// Scrollbars.
+	vertical, horizontal := sp.scrollbars()
+	if vertical {
+		sp.vScrollbar.SetSize(pixel.V(ConvSize(scrollbarWidth), view.H()))
+		sp.vScrollbar.Draw(t, pixel.IM.Moved(pixel.V(view.Max.X+ConvSize(scrollbarWidth)/2, view.Center().Y)))
+	}
+	if horizontal {
+		sp.hScrollbar.SetSize(pixel.V(view.W(), ConvSize(scrollbarWidth)))
+		sp.hScrollbar.Draw(t, pixel.IM.Moved(pixel.V(view.Center().X, view.Min.Y-ConvSize(scrollbarWidth)/2)))
+	}
+}
+
+// Update updates scroll pane content and handles
+// scrolling.
+func (sp *ScrollPane) Update(win Input) {
+	if sp.content == nil {
+		return
+	}
+	// Content.
+	sp.input.Input = win
+	sp.input.area = sp.viewArea()
+	sp.input.scrolled = false
+	sp.content.Update(sp.input)
+	// Mouse scroll, if not used by the content.
+	if !sp.input.scrolled && sp.DrawArea().Contains(win.MousePosition()) {
+		scroll := win.MouseScroll().Scaled(ConvSize(sp.scrollStep))
+		if shiftPressed(win) {
+			scroll = pixel.V(scroll.X-scroll.Y, 0)
+		}
+		sp.SetOffset(sp.offset.Add(pixel.V(scroll.X, -scroll.Y)))
+	}
+	// Scrollbars.
+	vertical, horizontal := sp.scrollbars()
+	view := sp.viewArea()
+	contentSize := sp.content.Size()
+	sp.vScrollbar.SetContent(int(contentSize.Y), int(view.H()))
+	sp.vScrollbar.SetValue(int(sp.offset.Y))
+	sp.hScrollbar.SetContent(int(contentSize.X), int(view.W()))
+	sp.hScrollbar.SetValue(int(sp.offset.X))
+	if vertical {
+		sp.vScrollbar.Update(win)
+	}
+	if horizontal {
+		sp.hScrollbar.Update(win)
+	}
+}
+
+// ApplyTheme sets color from panel style and scrollbar
+// colors from scrollbar style of specified theme.
+// Theme is also applied to the pane content.
+func (sp *ScrollPane) ApplyTheme(t *Theme) {
+	sp.color = t.Panel.MainColor
+	sp.vScrollbar.ApplyTheme(t)
+	sp.hScrollbar.ApplyTheme(t)
+	if sp.content != nil {
+		applyChildrenTheme([]Widget{sp.content}, t)
+	}
+}
+
+// SetContent sets specified widget as pane content.
+// Content scroll is reset.
+func (sp *ScrollPane) SetContent(w Widget) {
+	sp.content = w
+	sp.offset = pixel.ZV
+}
+
+// Content returns pane content.
+func (sp *ScrollPane) Content() Widget {
+	return sp.content
+}
+
+// SetOffset sets specified vector as content scroll, in
+// pixels from the content top left corner.
+// Offset is limited, so content always fills the pane.
+func (sp *ScrollPane) SetOffset(offset pixel.Vec) {
+	sp.offset = offset
+	if sp.content == nil {
+		return
+	}
+	view := sp.viewArea()
+	contentSize := sp.content.Size()
+	sp.offset.X = math.Max(0, math.Min(sp.offset.X, contentSize.X-view.W()))
+	sp.offset.Y = math.Max(0, math.Min(sp.offset.Y, contentSize.Y-view.H()))
+}
+
+// Offset returns current content scroll, in pixels from
+// the content top left corner.
+func (sp *ScrollPane) Offset() pixel.Vec {
+	return sp.offset
+}
+
+// SetScrollStep sets specified value(for 1080p) as
+// distance scrolled with single mouse wheel step.
+func (sp *ScrollPane) SetScrollStep(step float64) {
+	sp.scrollStep = step
+}
+
+// SetColor sets specified color as pane background
+// color.
+func (sp *ScrollPane) SetColor(c color.Color) {
+	sp.color = c
+}
+
+// SetSize sets pane size.
+func (sp *ScrollPane) SetSize(s pixel.Vec) {
+	sp.size = s
+}
+
+// Size returns pane size.
+func (sp *ScrollPane) Size() pixel.Vec {
+	return sp.size
+}
+
+// DrawArea returns current pane draw area.
+func (sp *ScrollPane) DrawArea() pixel.Rect {
+	return sp.drawArea
+}
+
+// scrollbars checks whether vertical and horizontal
+// scrollbars are needed to scroll the content.
+func (sp *ScrollPane) scrollbars() (vertical, horizontal bool) {
+	if sp.content == nil {
+		return
+	}
+	size := sp.DrawArea().Size()
+	contentSize := sp.content.Size()
+	width := ConvSize(scrollbarWidth)
+	vertical = contentSize.Y > size.Y
+	horizontal = contentSize.X > size.X
+	if vertical && !horizontal {
+		horizontal = contentSize.X > size.X-width
+	}
+	if horizontal && !vertical {
+		vertical = contentSize.Y > size.Y-width
+	}
+	return
+}
+
+// viewArea returns area of the pane that displays the
+// content, without scrollbars.
+func (sp *ScrollPane) viewArea() pixel.Rect {
+	view := sp.DrawArea()
+	vertical, horizontal := sp.scrollbars()
+	if vertical {
+		view.Max.X -= ConvSize(scrollbarWidth)
+	}
+	if horizontal {
+		view.Min.Y += ConvSize(scrollbarWidth)
+	}
+	return view
+}
+
+// Triggered after scrollbar value was changed.
+func (sp *ScrollPane) onScroll(s *Scrollbar) {
+	sp.SetOffset(pixel.V(float64(sp.hScrollbar.Value()), float64(sp.vScrollbar.Value())))
+}
+
+// MousePosition returns mouse position relative to the
+// pane canvas, or position outside of any UI element if
+// mouse is outside the pane.
+func (pi *paneInput) MousePosition() pixel.Vec {
+	pos := pi.Input.MousePosition()
+	if !pi.area.Contains(pos) {
+		return pixel.V(math.Inf(-1), math.Inf(-1))
+	}
+	return pos.Sub(pi.area.Min)
+}
+
+// MouseScroll returns mouse scroll in the current frame,
+// and marks the scroll as used by the pane content.
+// Content elements read mouse scroll only when mouse
+// is over them, so the pane is not scrolled together
+// with scrollable content.
+func (pi *paneInput) MouseScroll() pixel.Vec {
+	scroll := pi.Input.MouseScroll()
+	if scroll != pixel.ZV {
+		pi.scrolled = true
+	}
+	return scroll
+}
+
+// Clipboard returns text from the clipboard of the pane
+// input source, if the source has access to the clipboard.
+func (pi *paneInput) Clipboard() string {
+	if cb, ok := pi.Input.(ClipboardInput); ok {
+		return cb.Clipboard()
+	}
+	return ""
+}
+
+// SetClipboard sets specified text in the clipboard of the
+// pane input source, if the source has access to the
+// clipboard.
+func (pi *paneInput) SetClipboard(s string) {
+	if cb, ok := pi.Input.(ClipboardInput); ok {
+		cb.SetClipboard(s)
+	}
+}
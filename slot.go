@@ -31,10 +31,6 @@ import (
 	"github.com/gopxl/pixel/pixelgl"
 )
 
-var (
-	defSlotColor = pixel.RGBA{0.1, 0.1, 0.1, 0.5} // default color
-)
-
 // Struct for slot.
 type Slot struct {
 	bgSpr               *pixel.Sprite
@@ -62,21 +58,30 @@ func NewSlot(params Params) *Slot {
 	s := new(Slot)
 	// Background.
 	s.size = params.Size.SlotSize()
+	s.bgSpr = params.Background
+	if s.bgSpr == nil {
+		s.bgSpr = theme.Slot.Background
+	}
 	s.color = params.MainColor
 	if s.color == nil {
-		s.color = defSlotColor
+		s.color = theme.Slot.MainColor
 	}
 	// Labels & info.
 	s.fontSize = params.FontSize
 	labelParams := Params{
 		FontSize: s.fontSize,
+		Font:     params.Font,
+	}
+	if len(labelParams.Font) < 1 {
+		labelParams.Font = theme.Slot.Font
 	}
 	s.label = NewText(labelParams)
 	s.countLabel = NewText(labelParams)
 	s.countLabel.Align(AlignCenter)
 	infoParams := Params{
-		FontSize:  SizeSmall,
-		MainColor: pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		FontSize:  theme.InfoWindow.FontSize,
+		Font:      theme.InfoWindow.Font,
+		MainColor: theme.InfoWindow.SecColor,
 	}
 	s.info = NewInfoWindow(infoParams)
 	return s
@@ -118,7 +123,7 @@ func (s *Slot) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates slot.
-func (s *Slot) Update(win *Window) {
+func (s *Slot) Update(win Input) {
 	// Mouse position.
 	s.mousePos = win.MousePosition()
 	// Mouse events.
@@ -205,6 +210,16 @@ func (s *Slot) SetColor(c color.Color) {
 	s.color = c
 }
 
+// ApplyTheme sets color and background from slot
+// style of specified theme.
+func (s *Slot) ApplyTheme(t *Theme) {
+	s.color = t.Slot.MainColor
+	s.bgSpr = t.Slot.Background
+	s.label.ApplyTheme(t)
+	s.countLabel.ApplyTheme(t)
+	s.info.applyElementTheme(t)
+}
+
 // SetIcon sets specified sprite as current
 // slot icon.
 func (s *Slot) SetIcon(pic pixel.Picture) {
@@ -235,6 +250,19 @@ func (s *Slot) SetInfo(text string) {
 	s.info.SetText(text)
 }
 
+// SetLabelKey sets text for specified translation key,
+// formatted with specified arguments, as slot label.
+func (s *Slot) SetLabelKey(key string, args ...interface{}) {
+	s.label.SetTextKey(key, args...)
+}
+
+// SetInfoKey sets text for specified translation key,
+// formatted with specified arguments, as content of
+// slot info window.
+func (s *Slot) SetInfoKey(key string, args ...interface{}) {
+	s.info.SetTextKey(key, args...)
+}
+
 // Clear removes slot value, icon,
 // label and text.
 func (s *Slot) Clear() {
@@ -26,8 +26,6 @@ package mtk
 import (
 	"image/color"
 
-	"golang.org/x/image/colornames"
-
 	"github.com/gopxl/pixel"
 )
 
@@ -39,6 +37,8 @@ type SlotList struct {
 	drawArea   pixel.Rect
 	upButton   *Button
 	downButton *Button
+	scrollbar  *Scrollbar
+	wheel      wheelScroll
 	slots      []*Slot
 	spl        int // slots per line
 	lines      int
@@ -49,18 +49,26 @@ type SlotList struct {
 func NewSlotList(bgSize pixel.Vec, bgColor color.Color, slotSize Size) *SlotList {
 	sl := new(SlotList)
 	sl.bgSize = bgSize
+	sl.bgSpr = theme.SlotList.Background
 	sl.bgColor = bgColor
+	if sl.bgColor == nil {
+		sl.bgColor = theme.SlotList.MainColor
+	}
 	// Buttons.
 	buttonParams := Params{
-		Size:      SizeMini,
-		FontSize:  SizeMini,
-		Shape:     ShapeSquare,
-		MainColor: colornames.Red,
+		Size:       theme.ScrollButton.Size,
+		FontSize:   theme.SlotList.FontSize,
+		Shape:      ShapeSquare,
+		MainColor:  theme.ScrollButton.MainColor,
+		Background: theme.ScrollButton.Background,
 	}
 	sl.upButton = NewButton(buttonParams)
 	sl.upButton.SetOnClickFunc(sl.onUpButtonClicked)
 	sl.downButton = NewButton(buttonParams)
 	sl.downButton.SetOnClickFunc(sl.onDownButtonClicked)
+	// Scrollbar.
+	sl.scrollbar = NewScrollbar(Params{})
+	sl.scrollbar.SetOnScrollFunc(sl.onScroll)
 	// Calculating amount of slots based on background and
 	// buttons sizes.
 	slotSizeRaw := slotSize.SlotSize()
@@ -85,6 +93,10 @@ func (sl *SlotList) Draw(t pixel.Target, matrix pixel.Matrix) {
 	downButtonPos := MoveBR(sl.Size(), sl.downButton.Size())
 	sl.upButton.Draw(t, matrix.Moved(upButtonPos))
 	sl.downButton.Draw(t, matrix.Moved(downButtonPos))
+	// Scrollbar.
+	sl.scrollbar.SetContent(sl.totalLines(), sl.visibleLines())
+	sl.scrollbar.SetValue(sl.lineID)
+	drawScrollbar(t, matrix, sl.scrollbar, sl.Size(), sl.upButton.Size())
 	// Slots.
 	// TODO: too slow.
 	if len(sl.slots) < 1 {
@@ -121,6 +133,20 @@ func (sl *SlotList) Draw(t pixel.Target, matrix pixel.Matrix) {
 	}
 }
 
+// ApplyTheme sets color and background from slot list
+// style of specified theme.
+// Theme is also applied to all slots on the list.
+func (sl *SlotList) ApplyTheme(t *Theme) {
+	sl.bgColor = t.SlotList.MainColor
+	sl.bgSpr = t.SlotList.Background
+	applyScrollButtonTheme(sl.upButton, t, nil)
+	applyScrollButtonTheme(sl.downButton, t, nil)
+	sl.scrollbar.ApplyTheme(t)
+	for _, s := range sl.slots {
+		s.ApplyTheme(t)
+	}
+}
+
 // SetUpButtonBackground sets specified sprite as scroll up button
 // background.
 func (sl *SlotList) SetUpButtonBackground(s *pixel.Sprite) {
@@ -136,16 +162,36 @@ func (sl *SlotList) SetDownButtonBackground(s *pixel.Sprite) {
 }
 
 // Update updates list.
-func (sl *SlotList) Update(win *Window) {
+func (sl *SlotList) Update(win Input) {
+	// Mouse scroll.
+	if n := sl.wheel.update(win, sl.DrawArea()); n != 0 {
+		sl.scrollbar.SetValue(sl.lineID + n)
+		sl.setStartLine(sl.scrollbar.Value())
+	}
 	// Buttons.
 	sl.upButton.Update(win)
 	sl.downButton.Update(win)
+	sl.scrollbar.Update(win)
 	// Slots.
 	for _, s := range sl.slots {
 		s.Update(win)
 	}
 }
 
+// HandleAction handles specified user action.
+// Scroll actions scroll the list.
+func (sl *SlotList) HandleAction(a Action) bool {
+	switch a {
+	case ActionScrollUp:
+		sl.setStartLine(sl.lineID - 1)
+		return true
+	case ActionScrollDown:
+		sl.setStartLine(sl.lineID + 1)
+		return true
+	}
+	return false
+}
+
 // Add adds specified slot to list.
 func (sl *SlotList) Add(s *Slot) {
 	sl.slots = append(sl.slots, s)
@@ -181,6 +227,12 @@ func (sl *SlotList) Size() pixel.Vec {
 	return sl.bgSpr.Frame().Size()
 }
 
+// DrawArea returns current list background position
+// and size.
+func (sl *SlotList) DrawArea() pixel.Rect {
+	return sl.drawArea
+}
+
 // hoveredSlot returns currently hovered slot.
 func (sl *SlotList) hoveredSlot() *Slot {
 	for _, s := range sl.slots {
@@ -200,6 +252,22 @@ func (sl *SlotList) setStartLine(line int) {
 	sl.lineID = line
 }
 
+// totalLines returns number of lines needed to
+// display all slots.
+func (sl *SlotList) totalLines() int {
+	if sl.spl < 1 {
+		return 0
+	}
+	return (len(sl.slots) + sl.spl - 1) / sl.spl
+}
+
+// visibleLines returns number of lines displayed
+// at once, lines field holds ID of the last visible
+// line.
+func (sl *SlotList) visibleLines() int {
+	return sl.lines + 1
+}
+
 // Triggered after up button clicked.
 func (sl *SlotList) onUpButtonClicked(b *Button) {
 	sl.setStartLine(sl.lineID - 1)
@@ -209,3 +277,8 @@ func (sl *SlotList) onUpButtonClicked(b *Button) {
 func (sl *SlotList) onDownButtonClicked(b *Button) {
 	sl.setStartLine(sl.lineID + 1)
 }
+
+// Triggered after scrollbar value was changed.
+func (sl *SlotList) onScroll(s *Scrollbar) {
+	sl.setStartLine(s.Value())
+}
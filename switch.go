@@ -27,8 +27,6 @@ import (
 	"fmt"
 	"image/color"
 
-	"golang.org/x/image/colornames"
-
 	"github.com/gopxl/pixel"
 )
 
@@ -64,13 +62,18 @@ func NewSwitch(params Params) *Switch {
 	s := new(Switch)
 	// Background.
 	s.bgSpr = params.Background
+	if s.bgSpr == nil {
+		s.bgSpr = theme.Switch.Background
+	}
 	s.size = params.Size.SwitchSize()
 	s.color = params.MainColor
-	s.bgSpr = params.Background
+	if s.color == nil {
+		s.color = theme.Switch.MainColor
+	}
 	// Buttons.
 	buttonColor := params.SecColor
 	if buttonColor == nil {
-		buttonColor = colornames.Red
+		buttonColor = theme.Switch.SecColor
 	}
 	buttonParams := Params{
 		Size:      params.Size - 2,
@@ -83,14 +86,21 @@ func NewSwitch(params Params) *Switch {
 	s.nextButton.SetOnClickFunc(s.onNextButtonClicked)
 	// Label & info.
 	labelParams := Params{
-		SizeRaw:  pixel.V(s.Size().X, 0),
-		FontSize: params.Size - 1,
+		SizeRaw:     pixel.V(s.Size().X, 0),
+		FontSize:    params.Size - 1,
+		Font:        params.Font,
+		Overflow:    params.Overflow,
+		TextEffects: params.TextEffects,
+	}
+	if len(labelParams.Font) < 1 {
+		labelParams.Font = theme.Switch.Font
 	}
 	s.label = NewText(labelParams)
 	s.label.Align(AlignCenter)
 	infoParams := Params{
-		FontSize:  SizeSmall,
-		MainColor: pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		FontSize:  theme.InfoWindow.FontSize,
+		Font:      theme.InfoWindow.Font,
+		MainColor: theme.InfoWindow.SecColor,
 	}
 	s.info = NewInfoWindow(infoParams)
 	// Values.
@@ -132,7 +142,9 @@ func (s *Switch) Draw(t pixel.Target, matrix pixel.Matrix) {
 }
 
 // Update updates switch and all elements.
-func (s *Switch) Update(win *Window) {
+func (s *Switch) Update(win Input) {
+	s.label.Update(win)
+	s.valueText.Update(win)
 	if s.Disabled() {
 		return
 	}
@@ -150,6 +162,38 @@ func (s *Switch) Update(win *Window) {
 	s.nextButton.Update(win)
 }
 
+// HandleAction handles specified user action.
+// Accept action switches to the next value, cancel
+// action switches to the previous value.
+func (s *Switch) HandleAction(a Action) bool {
+	if s.Disabled() {
+		return false
+	}
+	switch a {
+	case ActionAccept:
+		s.onNextButtonClicked(s.nextButton)
+		return true
+	case ActionCancel:
+		s.onPrevButtonClicked(s.prevButton)
+		return true
+	}
+	return false
+}
+
+// ApplyTheme sets colors and background from switch
+// style of specified theme.
+func (s *Switch) ApplyTheme(t *Theme) {
+	s.color = t.Switch.MainColor
+	s.bgSpr = t.Switch.Background
+	s.prevButton.ApplyTheme(t)
+	s.prevButton.SetColor(t.Switch.SecColor)
+	s.nextButton.ApplyTheme(t)
+	s.nextButton.SetColor(t.Switch.SecColor)
+	s.label.ApplyTheme(t)
+	s.valueText.ApplyTheme(t)
+	s.info.applyElementTheme(t)
+}
+
 // SetBackground sets specified sprite as switch
 // background, also removes background color.
 func (s *Switch) SetBackground(spr *pixel.Sprite) {
@@ -168,11 +212,30 @@ func (s *Switch) SetLabel(t string) {
 	s.label.SetText(t)
 }
 
+// SetLabelEffects sets specified effects for label
+// and value text.
+func (s *Switch) SetLabelEffects(effects TextEffects) {
+	s.label.SetEffects(effects)
+	s.valueText.SetEffects(effects)
+}
+
 // SetInfo sets specified text as info.
 func (s *Switch) SetInfo(t string) {
 	s.info.SetText(t)
 }
 
+// SetLabelKey sets text for specified translation key,
+// formatted with specified arguments, as label.
+func (s *Switch) SetLabelKey(key string, args ...interface{}) {
+	s.label.SetTextKey(key, args...)
+}
+
+// SetInfoKey sets text for specified translation key,
+// formatted with specified arguments, as info.
+func (s *Switch) SetInfoKey(key string, args ...interface{}) {
+	s.info.SetTextKey(key, args...)
+}
+
 // SetNextButtonBackground sets specified sprite as next
 // button background.
 func (s *Switch) SetNextButtonBackground(spr *pixel.Sprite) {
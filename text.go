@@ -25,27 +25,77 @@ package mtk
 
 import (
 	"bytes"
-	"fmt"
 	"image/color"
-	"strings"
+	"unicode/utf8"
 
-	"golang.org/x/image/colornames"
+	"github.com/gopxl/beep"
 
 	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
 	"github.com/gopxl/pixel/text"
 )
 
 // Text struct for short text like labels, names, etc.
+// Text supports inline markup tags for colors, sizes,
+// fonts, bold and italic, see SetText.
+// Lines wider than maximal text width are handled
+// according to text overflow mode, see SetOverflow.
 type Text struct {
 	*text.Text
 	content  string
+	lines    []textLine
+	objects  []*textObject
 	drawArea pixel.Rect // updated on each draw
 	color    color.Color
+	font     string
 	fontSize Size
 	width    float64
+	wrap     Wrap
+	hyphens  bool
+	overflow Overflow
+	shrink   Size    // font size steps removed by shrink overflow
+	marquee  float64 // current scroll offset of marquee text
+	speed    float64 // marquee speed in pixels(for 1080p) per second
+	effects  TextEffects
+	reveal   textReveal
+	shown    int // number of shown characters, -1 for all
 	align    Align
+	layouts  map[string][]textLine // cached layouts of texts
+	tr       *translation
+	trRev    int // language revision of translated text
+	onReveal func(t *Text)
 }
 
+// Struct for text object with glyphs of single font,
+// size and synthetic font style.
+type textObject struct {
+	key      textObjectKey
+	text     *text.Text
+	effect   *text.Text // white copy of text for outline and shadow
+	atlas    *DynamicAtlas
+	atlasVer int     // version of atlas used by text
+	baseline float64 // baseline of italic line
+}
+
+// Struct for text object key.
+type textObjectKey struct {
+	font   string
+	size   Size
+	bold   bool // synthetic bold
+	italic bool // synthetic italic
+	line   int  // line index for synthetic italic
+}
+
+const (
+	// Slant of synthetic italic text.
+	italicSlant = 0.2
+	// Space between marquee text and its repetition,
+	// in pixels for 1080p.
+	marqueeGap = 50
+	// Maximal number of cached text layouts.
+	maxTextLayouts = 256
+)
+
 // NewText creates new text with specified
 // parameters.
 func NewText(p Params) *Text {
@@ -53,78 +103,338 @@ func NewText(p Params) *Text {
 	// Parameters.
 	t.fontSize = p.FontSize
 	t.width = p.SizeRaw.X
+	t.wrap = p.Wrap
+	t.hyphens = true
+	t.overflow = p.Overflow
+	t.speed = 50
+	t.effects = p.TextEffects
+	t.reveal = newTextReveal()
+	t.shown = -1
 	// Text.
-	font := MainFont(t.fontSize)
-	atlas := Atlas(&font)
-	t.Text = text.New(pixel.V(0, 0), atlas)
+	t.font = p.Font
+	if len(t.font) < 1 {
+		t.font = theme.Text.Font
+	}
+	t.Text = t.object(t.baseStyle(), 0).text
 	t.color = p.MainColor
 	if t.color == nil {
-		t.color = colornames.White // default color white
+		t.color = theme.Text.MainColor
 	}
 	t.align = AlignCenter
 	return t
 }
 
 // SetText sets specified text as text to display.
+// Text can contain markup tags:
+// [b]bold[/b], [i]italic[/i], [color=red]color[/color],
+// [size=big]size[/size] and [font=name]font[/font].
+// Bold and italic texts use fonts registered under
+// font name with '-bold', '-italic' or '-bold-italic'
+// suffix, if there is no such font then bold and
+// italic are synthesized.
+// Pause tag, [pause=500], pauses text reveal for specified
+// number of milliseconds, see SetRevealSpeed.
+// Use EscapeMarkup to display text with '[' characters.
 func (t *Text) SetText(text string) {
-	t.Clear()
-	// If text too wide, then split to more lines.
-	breakLines := t.breakLine(text, t.width)
-	for j := 0; j < len(breakLines); j++ { // reverse order
-		bl := breakLines[j]
-		t.content = fmt.Sprintf("%s%s", t.content, bl)
+	t.tr = nil
+	t.content = text
+	t.marquee = 0
+	t.lines = t.fit(text)
+	t.shown = -1
+	if t.reveal.speed > 0 {
+		pauses := make(map[int]int64)
+		t.reveal.start(linesReveal(t.lines, 0, pauses), pauses)
+		t.updateReveal()
 	}
-	t.Align(t.align)
+	t.render()
+}
+
+// SetTextKey sets text for specified translation key,
+// formatted with specified arguments, as text to display.
+// Text is updated after each language change.
+func (t *Text) SetTextKey(key string, args ...interface{}) {
+	t.setTranslation(&translation{key: key, args: args})
+}
+
+// SetTextKeyN sets plural form of text for specified translation
+// key and number, formatted with specified arguments, as text
+// to display.
+// Text is updated after each language change.
+func (t *Text) SetTextKeyN(key string, n int, args ...interface{}) {
+	t.setTranslation(&translation{key: key, n: n, plural: true, args: args})
 }
 
 // SetColor sets specified color as
 // current text color.
 func (tx *Text) SetColor(c color.Color) {
 	tx.color = c
+	tx.render()
 }
 
 // SetMaxWidth sets maximal width of single text line.
+// Width <= 0 means no width limit.
 func (tx *Text) SetMaxWidth(width float64) {
 	tx.width = width
+	tx.relayout()
+}
+
+// SetWrap sets specified wrap mode for lines longer than
+// maximal text width.
+// Word wrap(default) breaks lines between words and breaks
+// only words longer than the maximal width, char wrap breaks
+// lines on any character, and with no wrap lines are broken
+// only on new line characters.
+func (tx *Text) SetWrap(wrap Wrap) {
+	tx.wrap = wrap
+	tx.relayout()
+}
+
+// SetHyphenation enables/disables breaking words on soft
+// hyphens(U+00AD) with word wrap.
+// Hyphen is displayed at the end of the line broken on
+// soft hyphen, soft hyphens are never displayed otherwise.
+// Hyphenation is enabled by default.
+func (tx *Text) SetHyphenation(hyphens bool) {
+	tx.hyphens = hyphens
+	tx.relayout()
+}
+
+// SetOverflow sets specified overflow mode for lines wider
+// than maximal text width.
+// Wrap(default) breaks lines according to wrap mode,
+// ellipsis truncates lines and ends them with '...', clip
+// hides parts of lines outside maximal width, shrink uses
+// smaller font sizes until all lines fit(sizes set with
+// markup are not changed) and marquee scrolls text
+// horizontally over time, see SetMarqueeSpeed.
+// All modes except wrap never break lines, apart from new
+// line characters.
+// Clip and marquee texts are drawn through canvas shared
+// by all texts, so, like scroll pane, they require OpenGL
+// context.
+func (tx *Text) SetOverflow(overflow Overflow) {
+	tx.overflow = overflow
+	tx.marquee = 0
+	tx.relayout()
+}
+
+// SetEffects sets specified effects for text.
+// Text with outline and shadow is drawn over its
+// shadow and outline drawn in effect colors.
+// Gradient sets colors of characters without color
+// set with markup, characters of horizontal gradient
+// change color from left to right, characters of vertical
+// gradient change color with each line, from top to bottom.
+func (tx *Text) SetEffects(effects TextEffects) {
+	tx.effects = effects
+	tx.render()
+}
+
+// Effects returns current text effects.
+func (tx *Text) Effects() TextEffects {
+	return tx.effects
+}
+
+// SetRevealSpeed sets specified number of characters per
+// second as speed of typewriter reveal.
+// Text set after this call is revealed character by
+// character on each update, value <= 0 disables reveal.
+// Click on the text or skip key press reveals whole text,
+// see SetRevealSkipKeys.
+func (tx *Text) SetRevealSpeed(speed float64) {
+	tx.reveal.speed = speed
+	if speed <= 0 {
+		tx.SkipReveal()
+	}
+}
+
+// SetRevealSound sets specified audio as sound played
+// by toolkit audio player for revealed characters.
+// Sound is played at most once per update.
+func (tx *Text) SetRevealSound(s *beep.Buffer) {
+	tx.reveal.sound = s
+}
+
+// SetRevealSkipKeys sets specified keys as keys that reveal
+// whole text, by default space and enter.
+// Skip keys work only when text is under mouse cursor.
+func (tx *Text) SetRevealSkipKeys(keys ...pixelgl.Button) {
+	tx.reveal.skipKeys = keys
+}
+
+// SkipReveal reveals whole text.
+func (tx *Text) SkipReveal() {
+	if !tx.reveal.active {
+		return
+	}
+	tx.reveal.skip()
+	tx.revealed()
+}
+
+// Revealing checks if text reveal is in progress.
+func (tx *Text) Revealing() bool {
+	return tx.reveal.active
+}
+
+// SetOnRevealFinishedFunc sets specified function as
+// function triggered after text reveal is finished or
+// skipped.
+func (tx *Text) SetOnRevealFinishedFunc(f func(t *Text)) {
+	tx.onReveal = f
+}
+
+// SetMarqueeSpeed sets specified value as speed of marquee
+// text scrolling, in pixels(for 1080p) per second.
+func (tx *Text) SetMarqueeSpeed(speed float64) {
+	tx.speed = speed
+}
+
+// ApplyTheme sets color from text style of specified
+// theme.
+func (tx *Text) ApplyTheme(t *Theme) {
+	tx.SetColor(t.Text.MainColor)
 }
 
 // Align aligns text to specified position.
 func (t *Text) Align(a Align) {
 	t.align = a
-	switch a {
-	case AlignCenter:
-		mariginX := (-t.BoundsOf(t.content).Max.X) / 2
-		t.Orig = pixel.V(mariginX, 0)
-		t.Text.Clear()
-		t.WriteString(t.content)
-	case AlignRight:
-		mariginX := (-t.BoundsOf(t.content).Max.X)
-		t.Orig = pixel.V(mariginX, 0)
-		t.Text.Clear()
-		t.WriteString(t.content)
-	case AlignLeft:
-		t.Orig = pixel.V(0, 0)
-		t.Text.Clear()
-		t.WriteString(t.content)
-	}
 }
 
 // Draw draws text.
 func (tx *Text) Draw(t pixel.Target, matrix pixel.Matrix) {
+	tx.updateTranslation()
 	tx.drawArea = MatrixToDrawArea(matrix, tx.Size())
-	tx.Text.DrawColorMask(t, matrix, tx.color)
+	bounds := tx.bounds()
+	if tx.clipped() {
+		tx.drawClipped(t, matrix, bounds)
+		return
+	}
+	move := pixel.ZV.Sub(bounds.Min)
+	switch tx.align {
+	case AlignCenter:
+		move.X -= bounds.W() / 2
+	case AlignRight:
+		move.X -= bounds.W()
+	}
+	tx.drawObjects(t, matrix, move)
+}
+
+// Update updates text.
+// Marquee text is scrolled with time.
+// Revealed text reveals next characters.
+func (tx *Text) Update(win Input) {
+	if tx.reveal.updateInput(win, tx.DrawArea(), false) {
+		tx.revealed()
+	}
+	if tx.overflow != OverflowMarquee || !tx.clipped() {
+		return
+	}
+	tx.marquee += ConvSize(tx.speed) * float64(win.Delta()) / 1000
+	loop := tx.bounds().W() + ConvSize(marqueeGap)
+	for tx.marquee >= loop {
+		tx.marquee -= loop
+	}
 }
 
 // Size returns size of current text.
+// Width of clipped and marquee text is limited to
+// the maximal text width.
 func (tx *Text) Size() pixel.Vec {
-	return tx.Text.Bounds().Size()
+	tx.updateTranslation()
+	size := tx.bounds().Size()
+	if tx.clipped() {
+		size.X = tx.width
+	}
+	return size
+}
+
+// drawObjects draws all text objects with specified
+// matrix, moved by specified vector.
+// Shadow and outline are drawn before the text.
+func (tx *Text) drawObjects(t pixel.Target, matrix pixel.Matrix, move pixel.Vec) {
+	if tx.effects.shadow() {
+		shadowMove := move.Add(ConvVec(tx.effects.ShadowOffset))
+		for _, o := range tx.objects {
+			o.draw(t, o.effect, matrix, shadowMove, tx.effects.ShadowColor)
+		}
+	}
+	if tx.effects.outline() {
+		thickness := ConvSize(tx.effects.OutlineThickness)
+		for _, d := range outlineDirections {
+			outlineMove := move.Add(d.Unit().Scaled(thickness))
+			for _, o := range tx.objects {
+				o.draw(t, o.effect, matrix, outlineMove, tx.effects.OutlineColor)
+			}
+		}
+	}
+	for _, o := range tx.objects {
+		o.draw(t, o.text, matrix, move, nil)
+	}
+}
+
+// drawClipped draws text with specified bounds on clip
+// canvas, and draws part of canvas with maximal text width
+// with specified matrix.
+// Marquee text is drawn with current scroll offset, and
+// repeated after the text end.
+func (tx *Text) drawClipped(t pixel.Target, matrix pixel.Matrix, bounds pixel.Rect) {
+	clip := pixel.R(0, 0, tx.width, bounds.H())
+	canvas := clipCanvasFor(clip)
+	canvas.Clear(pixel.Alpha(0))
+	move := pixel.ZV.Sub(bounds.Min)
+	switch {
+	case tx.overflow == OverflowMarquee:
+		move.X -= tx.marquee
+		repeatMove := move.Add(pixel.V(bounds.W()+ConvSize(marqueeGap), 0))
+		tx.drawObjects(canvas, pixel.IM, repeatMove)
+	case tx.align == AlignCenter:
+		move.X += (tx.width - bounds.W()) / 2
+	case tx.align == AlignRight:
+		move.X += tx.width - bounds.W()
+	}
+	tx.drawObjects(canvas, pixel.IM, move)
+	clipMove := pixel.V(0, bounds.H()/2)
+	switch tx.align {
+	case AlignLeft:
+		clipMove.X = tx.width / 2
+	case AlignRight:
+		clipMove.X = -tx.width / 2
+	}
+	clipSprite.Set(canvas, clip)
+	clipSprite.Draw(t, pixel.IM.Moved(clipMove).Chained(matrix))
+}
+
+// clipCanvasFor returns canvas shared by clipped texts,
+// with bounds that contain specified rectangle.
+// Canvas is never shrunk, to avoid reallocation for texts
+// of different sizes.
+func clipCanvasFor(r pixel.Rect) *pixelgl.Canvas {
+	if clipCanvas == nil {
+		clipCanvas = pixelgl.NewCanvas(r)
+		clipSprite = pixel.NewSprite(clipCanvas, r)
+	}
+	b := clipCanvas.Bounds()
+	if b.W() < r.W() || b.H() < r.H() {
+		clipCanvas.SetBounds(pixel.R(0, 0, max(b.W(), r.W()), max(b.H(), r.H())))
+	}
+	return clipCanvas
+}
+
+// clipped checks if text is wider than maximal text width
+// and has to be clipped.
+func (tx *Text) clipped() bool {
+	if tx.width <= 0 || (tx.overflow != OverflowClip && tx.overflow != OverflowMarquee) {
+		return false
+	}
+	return tx.bounds().W() > tx.width
 }
 
 // Clear clears texts,
 func (t *Text) Clear() {
-	t.Text.Clear()
 	t.content = ""
-	t.Align(t.align)
+	t.lines = nil
+	t.marquee = 0
+	t.render()
 }
 
 // DrawArea returns current draw area of text.
@@ -137,41 +447,288 @@ func (tx *Text) String() string {
 	return tx.content
 }
 
-// breakLine breaks specified line into few lines with specified
-// maximal width.
-func (t *Text) breakLine(line string, width float64) []string {
-	lines := make([]string, 0)
-	lineWidth := t.BoundsOf(line).W()
-	if width > 0 && lineWidth > width {
-		breakPoint := t.breakPoint(line, width)
-		breakLines := SplitSubN(line, breakPoint)
-		for i, l := range breakLines {
-			if !strings.HasSuffix(l, "\n") {
-				breakLines[i] += "\n"
+// layout parses markup in specified text and breaks text
+// into lines with maximal text width.
+// Layouts are cached until the change of text width or
+// wrap mode.
+func (t *Text) layout(content string) []textLine {
+	if lines, ok := t.layouts[content]; ok {
+		return lines
+	}
+	runs := parseMarkup(content, t.baseStyle())
+	for _, r := range runs {
+		o := t.object(r.style, 0)
+		o.atlas.AddRunes([]rune(r.text + ellipsis)...)
+	}
+	opts := layoutOptions{
+		width:       t.width,
+		wrap:        t.wrap,
+		hyphenation: t.hyphens,
+	}
+	if t.overflow != OverflowWrap {
+		opts.wrap = WrapNone
+	}
+	lines := layoutRuns(runs, opts, t.styleAtlas)
+	if t.layouts == nil || len(t.layouts) >= maxTextLayouts {
+		t.layouts = make(map[string][]textLine)
+	}
+	t.layouts[content] = lines
+	return lines
+}
+
+// relayout clears cached layouts and breaks current
+// text into lines again.
+func (t *Text) relayout() {
+	t.layouts = nil
+	t.lines = t.fit(t.content)
+	t.render()
+}
+
+// fit breaks specified text into lines and applies
+// overflow mode to lines wider than maximal text width.
+func (t *Text) fit(content string) []textLine {
+	if t.shrink > 0 {
+		t.shrink = 0
+		t.layouts = nil
+	}
+	lines := t.layout(content)
+	if t.width <= 0 {
+		return lines
+	}
+	switch t.overflow {
+	case OverflowEllipsis:
+		fitted := make([]textLine, len(lines))
+		for i, l := range lines {
+			fitted[i] = l
+			if l.width > t.width {
+				fitted[i] = ellipsizeLine(l, t.width, t.styleAtlas)
 			}
 		}
-		lines = append(lines, breakLines...)
-	} else {
-		lines = append(lines, line)
+		return fitted
+	case OverflowShrink:
+		for t.shrink < t.fontSize && linesWidth(lines) > t.width {
+			t.shrink++
+			t.layouts = nil
+			lines = t.layout(content)
+		}
 	}
 	return lines
 }
 
-// breakPoint return break position for specified line and width.
-func (t *Text) breakPoint(line string, width float64) int {
-	checkLine := ""
-	breakPoint := -1
-	for _, c := range line {
-		if c == '\n' {
-			breakPoint = -1
+// styleAtlas returns atlas for specified text style.
+func (t *Text) styleAtlas(s textStyle) *text.Atlas {
+	return t.object(s, 0).atlas.Atlas()
+}
+
+// setLines sets specified lines as text to display.
+func (t *Text) setLines(lines []textLine) {
+	t.lines = lines
+	t.render()
+}
+
+// render writes all text lines to text objects.
+// Objects for outline and shadow effects are written
+// in white, so effects can be drawn with color mask.
+// Characters not revealed yet are written transparent,
+// so text size does not change during reveal.
+func (t *Text) render() {
+	for _, o := range t.objects {
+		if o.atlasVer != o.atlas.Version() {
+			o.text = text.New(pixel.ZV, o.atlas.Atlas())
+			o.effect = nil
+			o.atlasVer = o.atlas.Version()
+		}
+		o.text.Clear()
+		t.updateEffect(o)
+	}
+	t.Text = t.object(t.baseStyle(), 0).text
+	width := linesWidth(t.lines)
+	count := 0 // number of written characters
+	y := 0.0
+	for i, l := range t.lines {
+		if i > 0 {
+			y -= l.height
+		}
+		for _, s := range l.spans {
+			o := t.object(s.style, i)
+			o.baseline = y
+			shown, hidden := s.text, ""
+			if t.shown >= 0 {
+				shown, hidden = splitRunes(s.text, t.shown-count)
+			}
+			count += utf8.RuneCountInString(s.text)
+			o.text.Dot = pixel.V(s.x, y)
+			t.writeSpan(o, s, shown, i, width)
+			o.text.Color = pixel.Alpha(0)
+			o.text.WriteString(hidden)
+			if o.effect != nil {
+				o.effect.Dot = pixel.V(s.x, y)
+				o.effect.Color = pixel.RGB(1, 1, 1)
+				o.effect.WriteString(shown)
+				o.effect.Color = pixel.Alpha(0)
+				o.effect.WriteString(hidden)
+			}
+		}
+	}
+}
+
+// writeSpan writes specified text of specified span, from
+// line with specified index, to specified text object.
+// Text is written with span color or text gradient color,
+// horizontal gradient uses specified text width.
+func (t *Text) writeSpan(o *textObject, s textSpan, txt string, line int, width float64) {
+	switch {
+	case s.style.color != nil:
+		o.text.Color = s.style.color
+	case t.effects.gradient() && t.effects.Gradient == GradientVertical:
+		o.text.Color = t.effects.gradientColor(float64(line) / float64(max(len(t.lines)-1, 1)))
+	case t.effects.gradient() && t.effects.Gradient == GradientHorizontal:
+		// Each character with its own color.
+		for _, r := range txt {
+			center := o.text.Dot.X + runeAdvance(o.atlas.Atlas(), -1, r)/2
+			o.text.Color = t.effects.gradientColor(center / max(width, 1))
+			o.text.WriteRune(r)
 		}
-		checkLine += string(c)
-		breakPoint++
-		if t.BoundsOf(checkLine).W() >= width {
-			return breakPoint
+		return
+	default:
+		o.text.Color = t.color
+	}
+	o.text.WriteString(txt)
+}
+
+// updateEffect creates or clears object for outline and
+// shadow effects of specified text object, or removes
+// it if there are no such effects.
+func (t *Text) updateEffect(o *textObject) {
+	if !t.effects.outline() && !t.effects.shadow() {
+		o.effect = nil
+		return
+	}
+	if o.effect == nil {
+		o.effect = text.New(pixel.ZV, o.atlas.Atlas())
+	}
+	o.effect.Clear()
+}
+
+// bounds returns bounds of all text objects.
+func (t *Text) bounds() pixel.Rect {
+	bounds := pixel.Rect{}
+	for _, o := range t.objects {
+		b := o.text.Bounds()
+		if b.Area() == 0 {
+			continue
+		}
+		if bounds.Area() == 0 {
+			bounds = b
+			continue
+		}
+		bounds = bounds.Union(b)
+	}
+	return bounds
+}
+
+// baseStyle returns style of text without markup.
+func (t *Text) baseStyle() textStyle {
+	return textStyle{font: t.font, size: t.fontSize - t.shrink}
+}
+
+// object returns text object for specified style and
+// line, new object is created if there is no object for
+// such style yet.
+func (t *Text) object(s textStyle, line int) *textObject {
+	key := textObjectKey{font: s.font, size: s.size}
+	if len(key.font) < 1 {
+		key.font = MainFontName
+	}
+	switch {
+	case s.bold && s.italic && fontRegistered(key.font+"-bold-italic"):
+		key.font += "-bold-italic"
+	case s.bold && s.italic && fontRegistered(key.font+"-bold"):
+		key.font += "-bold"
+		key.italic = true
+	case s.bold && s.italic && fontRegistered(key.font+"-italic"):
+		key.font += "-italic"
+		key.bold = true
+	case s.bold && fontRegistered(key.font+"-bold"):
+		key.font += "-bold"
+	case s.italic && fontRegistered(key.font+"-italic"):
+		key.font += "-italic"
+	default:
+		key.bold = s.bold
+		key.italic = s.italic
+	}
+	if key.italic {
+		key.line = line
+	}
+	for _, o := range t.objects {
+		if o.key == key {
+			return o
 		}
 	}
-	return len(line) - 1
+	o := &textObject{key: key}
+	o.atlas = FontAtlas(key.font, key.size)
+	o.atlasVer = o.atlas.Version()
+	o.text = text.New(pixel.ZV, o.atlas.Atlas())
+	t.updateEffect(o)
+	t.objects = append(t.objects, o)
+	return o
+}
+
+// draw draws specified text of text object with specified
+// matrix, moved by specified vector, and multiplied by
+// specified color mask.
+// Text is drawn with its bounds minimum at the matrix
+// position.
+func (o *textObject) draw(t pixel.Target, txt *text.Text, matrix pixel.Matrix, move pixel.Vec, mask color.Color) {
+	if txt.Bounds().Area() == 0 {
+		return
+	}
+	objMatrix := pixel.IM.Moved(txt.Bounds().Min.Add(move))
+	if o.key.italic {
+		baseline := o.baseline + move.Y
+		objMatrix = objMatrix.Chained(pixel.Matrix{1, 0, italicSlant, 1, -italicSlant * baseline, 0})
+	}
+	txt.DrawColorMask(t, objMatrix.Chained(matrix), mask)
+	if o.key.bold {
+		txt.DrawColorMask(t, objMatrix.Moved(pixel.V(1, 0)).Chained(matrix), mask)
+	}
+}
+
+// updateReveal updates number of shown characters to
+// number of characters revealed by text reveal.
+func (t *Text) updateReveal() {
+	t.shown = -1
+	if t.reveal.active {
+		t.shown = int(t.reveal.shown)
+	}
+}
+
+// revealed updates shown characters after change of
+// revealed characters, and triggers reveal finish function
+// if reveal was finished.
+func (t *Text) revealed() {
+	t.updateReveal()
+	t.render()
+	if !t.reveal.active && t.onReveal != nil {
+		t.onReveal(t)
+	}
+}
+
+// setTranslation sets text from specified translation as
+// text to display.
+func (t *Text) setTranslation(tr *translation) {
+	t.SetText(tr.text())
+	t.tr = tr
+	t.trRev = langRevision
+}
+
+// updateTranslation updates translated text if language
+// was changed since last update.
+func (t *Text) updateTranslation() {
+	if t.tr == nil || t.trRev == langRevision {
+		return
+	}
+	t.setTranslation(t.tr)
 }
 
 // Splits string to chunks with n as max chunk width.
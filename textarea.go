@@ -0,0 +1,504 @@
+/*
+ * textarea.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+	"github.com/gopxl/pixel/text"
+)
+
+// Struct for multi-line text areas.
+// Text area wraps its text to the area width and scrolls
+// it vertically, like textbox, and supports editing like
+// text edit: caret movement with arrow, Home, End, PageUp
+// and PageDown keys or mouse, text selection, clipboard
+// and undo/redo shortcuts.
+// Enter inserts new line.
+type TextArea struct {
+	textEditor
+	size       pixel.Vec
+	drawArea   pixel.Rect   // updated on each draw
+	matrix     pixel.Matrix // updated on each draw
+	color      color.Color
+	colorFocus color.Color
+	colorSel   color.Color
+	input      *text.Text
+	atlas      *DynamicAtlas
+	atlasVer   int // version of atlas used by input
+	upButton   *Button
+	downButton *Button
+	wheel      wheelScroll
+	lines      []textRange // wrapped lines of text
+	wrapWidth  float64     // width used to wrap lines
+	startID    int         // ID of first visible line
+	caretX     float64     // caret position kept during vertical movement
+	dragging   bool
+	focused    bool
+	disabled   bool
+}
+
+// NewTextArea creates new text area with specified
+// parameters.
+func NewTextArea(params Params) *TextArea {
+	ta := new(TextArea)
+	// Background.
+	ta.size = params.SizeRaw
+	ta.color = params.MainColor
+	if ta.color == nil {
+		ta.color = theme.TextArea.MainColor
+	}
+	ta.colorFocus = theme.TextArea.FocusedColor
+	ta.colorSel = theme.TextArea.AccentColor
+	// Text input.
+	font := params.Font
+	if len(font) < 1 {
+		font = theme.TextArea.Font
+	}
+	ta.atlas = FontAtlas(font, params.FontSize)
+	ta.atlasVer = ta.atlas.Version()
+	ta.input = text.New(pixel.V(0, 0), ta.atlas.Atlas())
+	ta.matrix = pixel.IM
+	ta.lines = []textRange{{}}
+	ta.textEditor = newTextEditor(true)
+	ta.onChange = ta.onTextChange
+	ta.onMove = ta.onCaretMove
+	// Buttons.
+	buttonParams := Params{
+		Size:       theme.ScrollButton.Size,
+		FontSize:   theme.ScrollButton.FontSize,
+		Shape:      ShapeSquare,
+		MainColor:  params.AccentColor,
+		Background: theme.ScrollButton.Background,
+	}
+	if buttonParams.MainColor == nil {
+		buttonParams.MainColor = theme.ScrollButton.MainColor
+	}
+	ta.upButton = NewButton(buttonParams)
+	ta.upButton.SetOnClickFunc(ta.onButtonUpClicked)
+	ta.downButton = NewButton(buttonParams)
+	ta.downButton.SetOnClickFunc(ta.onButtonDownClicked)
+	return ta
+}
+
+// Draw draws text area.
+func (ta *TextArea) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Draw area.
+	ta.drawArea = MatrixToDrawArea(matrix, ta.Size())
+	ta.matrix = matrix
+	if ta.textWidth() != ta.wrapWidth {
+		ta.wrap()
+	}
+	color := ta.color
+	if ta.Focused() && ta.colorFocus != nil {
+		color = ta.colorFocus
+	}
+	DrawRect(t, ta.DrawArea(), color)
+	// Selection.
+	origin := ta.textOrigin()
+	atlas := ta.atlas.Atlas()
+	start, end := ta.Selection()
+	if ta.Focused() && start != end && ta.colorSel != nil {
+		for i := ta.startID; i < len(ta.lines) && i < ta.startID+ta.visibleLines(); i++ {
+			selStart, selEnd := max(start, ta.lines[i].start), min(end, ta.lines[i].end)
+			if selStart >= selEnd {
+				continue
+			}
+			lineOrigin := origin.Sub(pixel.V(0, float64(i-ta.startID)*atlas.LineHeight()))
+			selMin := matrix.Project(lineOrigin.Add(pixel.V(ta.lineX(i, selStart), -atlas.Descent())))
+			selMax := matrix.Project(lineOrigin.Add(pixel.V(ta.lineX(i, selEnd), atlas.Ascent())))
+			sel := pixel.R(selMin.X, selMin.Y, selMax.X, selMax.Y).Intersect(ta.DrawArea())
+			if sel.Area() > 0 {
+				DrawRect(t, sel, ta.colorSel)
+			}
+		}
+	}
+	// Text input.
+	if ta.input.Bounds().Area() > 0 {
+		inputMatrix := pixel.IM.Moved(ta.input.Bounds().Min.Add(origin))
+		ta.input.Draw(t, inputMatrix.Chained(matrix))
+	}
+	// Caret.
+	line := ta.caretLine()
+	if ta.Focused() && (ta.blink/caretBlink)%2 == 0 && line >= ta.startID &&
+		line < ta.startID+ta.visibleLines() {
+		caretPos := origin.Add(pixel.V(ta.lineX(line, ta.caret), -float64(line-ta.startID)*atlas.LineHeight()))
+		caretMin := matrix.Project(caretPos.Add(pixel.V(0, -atlas.Descent())))
+		caretMax := matrix.Project(caretPos.Add(pixel.V(0, atlas.Ascent())))
+		caret := pixel.R(caretMin.X, caretMin.Y, caretMin.X+math.Max(1, ConvSize(2)), caretMax.Y)
+		DrawRect(t, caret, ta.input.Color)
+	}
+	// Buttons.
+	upButtonPos := MoveTR(ta.Size(), ta.upButton.Size())
+	downButtonPos := MoveBR(ta.Size(), ta.downButton.Size())
+	ta.upButton.Draw(t, matrix.Moved(upButtonPos))
+	ta.downButton.Draw(t, matrix.Moved(downButtonPos))
+}
+
+// Update updates text area.
+func (ta *TextArea) Update(win Input) {
+	if ta.Disabled() {
+		return
+	}
+	ta.blink += win.Delta()
+	// Mouse events.
+	mousePos := win.MousePosition()
+	onButtons := ta.upButton.DrawArea().Contains(mousePos) ||
+		ta.downButton.DrawArea().Contains(mousePos)
+	if win.JustPressed(pixelgl.MouseButtonLeft) {
+		switch {
+		case !ta.DrawArea().Contains(mousePos):
+			ta.Focus(false)
+		case !onButtons:
+			ta.Focus(true)
+			ta.dragging = true
+			ta.moveCaret(ta.mouseCaret(mousePos), shiftPressed(win))
+		}
+	}
+	if ta.dragging && win.Pressed(pixelgl.MouseButtonLeft) {
+		ta.moveCaret(ta.mouseCaret(mousePos), true)
+	}
+	if win.JustReleased(pixelgl.MouseButtonLeft) {
+		ta.dragging = false
+	}
+	if n := ta.wheel.update(win, ta.DrawArea()); n != 0 {
+		ta.scroll(n)
+	}
+	// Key events.
+	if ta.focused {
+		ta.updateKeys(win)
+		if typed := win.Typed(); len(typed) > 0 {
+			ta.insert(typed, editTyping)
+		}
+	}
+	// Elements.
+	ta.upButton.Update(win)
+	ta.downButton.Update(win)
+	ta.updateInput()
+}
+
+// HandleAction handles specified user action.
+// Scroll actions scroll the text.
+func (ta *TextArea) HandleAction(a Action) bool {
+	switch a {
+	case ActionScrollUp:
+		ta.scroll(-1)
+		return true
+	case ActionScrollDown:
+		ta.scroll(1)
+		return true
+	}
+	return false
+}
+
+// ApplyTheme sets colors from text area style of
+// specified theme.
+func (ta *TextArea) ApplyTheme(t *Theme) {
+	ta.color = t.TextArea.MainColor
+	ta.colorFocus = t.TextArea.FocusedColor
+	ta.colorSel = t.TextArea.AccentColor
+	applyScrollButtonTheme(ta.upButton, t, nil)
+	applyScrollButtonTheme(ta.downButton, t, nil)
+}
+
+// Focus sets or removes focus from text area.
+func (ta *TextArea) Focus(focus bool) {
+	ta.focused = focus
+	ta.blink = 0
+}
+
+// Focused checks whether text area is focused.
+func (ta *TextArea) Focused() bool {
+	return ta.focused
+}
+
+// Active toggles text area activity.
+func (ta *TextArea) Active(active bool) {
+	ta.disabled = !active
+}
+
+// Disabled checks whether text area is disabled.
+func (ta *TextArea) Disabled() bool {
+	return ta.disabled
+}
+
+// SetUpButtonBackground sets specified sprite as scroll
+// up button background.
+func (ta *TextArea) SetUpButtonBackground(s *pixel.Sprite) {
+	ta.upButton.SetBackground(s)
+}
+
+// SetDownButtonBackground sets specified sprite as scroll
+// down button background.
+func (ta *TextArea) SetDownButtonBackground(s *pixel.Sprite) {
+	ta.downButton.SetBackground(s)
+}
+
+// UsesArrowKeys checks whether text area uses arrow keys
+// to move the caret, i.e. is focused.
+// Focus manager does not move focus with arrow keys while
+// text area is focused.
+func (ta *TextArea) UsesArrowKeys() bool {
+	return ta.Focused()
+}
+
+// SetSize sets text area size.
+func (ta *TextArea) SetSize(size pixel.Vec) {
+	ta.size = size
+}
+
+// Size returns text area size.
+func (ta *TextArea) Size() pixel.Vec {
+	return ta.size
+}
+
+// DrawArea returns current draw area rectangle.
+func (ta *TextArea) DrawArea() pixel.Rect {
+	return ta.drawArea
+}
+
+// updateKeys handles caret movement, new line, removing
+// and clipboard keys pressed or repeated in the current
+// frame.
+// Up, Down, PageUp and PageDown keys move caret between
+// lines, Home and End keys move caret to the line start
+// and end, or to the text start and end with Ctrl.
+func (ta *TextArea) updateKeys(win Input) {
+	shift := shiftPressed(win)
+	ctrl := ctrlPressed(win)
+	line := ta.caretLine()
+	switch {
+	case keyPressed(win, pixelgl.KeyUp):
+		ta.moveLines(-1, shift)
+	case keyPressed(win, pixelgl.KeyDown):
+		ta.moveLines(1, shift)
+	case keyPressed(win, pixelgl.KeyPageUp):
+		ta.moveLines(-ta.visibleLines(), shift)
+	case keyPressed(win, pixelgl.KeyPageDown):
+		ta.moveLines(ta.visibleLines(), shift)
+	case keyPressed(win, pixelgl.KeyHome) && !ctrl:
+		ta.moveCaret(ta.lines[line].start, shift)
+	case keyPressed(win, pixelgl.KeyEnd) && !ctrl:
+		ta.moveCaret(ta.lineEnd(line), shift)
+	case keyPressed(win, pixelgl.KeyEnter), keyPressed(win, pixelgl.KeyKPEnter):
+		ta.insert("\n", editTyping)
+	default:
+		ta.textEditor.updateKeys(win)
+	}
+}
+
+// onTextChange wraps text after text area value change.
+func (ta *TextArea) onTextChange() {
+	ta.updateAtlas()
+	ta.wrap()
+}
+
+// onCaretMove stores horizontal caret position and
+// updates text scroll after caret move.
+func (ta *TextArea) onCaretMove() {
+	ta.caretX = ta.lineX(ta.caretLine(), ta.caret)
+	ta.updateScroll()
+}
+
+// moveLines moves caret by specified number of lines,
+// as close as possible to its previous horizontal position.
+// Caret is moved to the text start or end if there is no
+// line to move to.
+func (ta *TextArea) moveLines(n int, selection bool) {
+	line := ta.caretLine()
+	target := max(0, min(line+n, len(ta.lines)-1))
+	switch {
+	case target == line && n < 0:
+		ta.moveCaret(0, selection)
+	case target == line:
+		ta.moveCaret(len([]rune(ta.text)), selection)
+	default:
+		x := ta.caretX
+		ta.moveCaret(ta.lineCaret(target, x), selection)
+		ta.caretX = x
+	}
+}
+
+// mouseCaret returns caret position closest to specified
+// mouse position.
+func (ta *TextArea) mouseCaret(mousePos pixel.Vec) int {
+	pos := ta.matrix.Unproject(mousePos).Sub(ta.textOrigin())
+	lineHeight := ta.atlas.Atlas().LineHeight()
+	line := ta.startID + int(math.Floor((ta.atlas.Atlas().Ascent()-pos.Y)/lineHeight))
+	line = max(0, min(line, len(ta.lines)-1))
+	return ta.lineCaret(line, pos.X)
+}
+
+// scroll scrolls text by specified number of lines.
+func (ta *TextArea) scroll(n int) {
+	ta.startID = max(0, min(ta.startID+n, len(ta.lines)-ta.visibleLines()))
+}
+
+// updateScroll updates vertical scroll of text, so
+// caret line is always visible.
+func (ta *TextArea) updateScroll() {
+	line := ta.caretLine()
+	visible := ta.visibleLines()
+	switch {
+	case line < ta.startID:
+		ta.startID = line
+	case line >= ta.startID+visible:
+		ta.startID = line - visible + 1
+	}
+	ta.scroll(0)
+}
+
+// updateInput writes visible lines of text area value
+// to the input text.
+func (ta *TextArea) updateInput() {
+	ta.input.Clear()
+	runes := []rune(ta.text)
+	lineHeight := ta.atlas.Atlas().LineHeight()
+	for i := ta.startID; i < len(ta.lines) && i < ta.startID+ta.visibleLines(); i++ {
+		ta.input.Dot = pixel.V(0, -float64(i-ta.startID)*lineHeight)
+		ta.input.WriteString(string(runes[ta.lines[i].start:ta.lines[i].end]))
+	}
+}
+
+// wrap splits text area value into lines that fit the
+// text width, with word wrap of text layout.
+func (ta *TextArea) wrap() {
+	ta.wrapWidth = ta.textWidth()
+	atlas := ta.atlas.Atlas()
+	runes := []rune(ta.text)
+	items := make([]layoutItem, len(runes))
+	for i, r := range runes {
+		items[i] = layoutItem{r: r, atlas: atlas}
+	}
+	ta.lines = breakLines(items, layoutOptions{width: ta.wrapWidth, wrap: WrapWord})
+	ta.updateScroll()
+	ta.updateInput()
+}
+
+// caretLine returns ID of line with the caret.
+// Caret at the end of wrapped line is placed at the
+// start of the next line.
+func (ta *TextArea) caretLine() int {
+	for i := range ta.lines[:len(ta.lines)-1] {
+		if ta.caret < ta.lines[i+1].start {
+			return i
+		}
+	}
+	return len(ta.lines) - 1
+}
+
+// lineEnd returns the last caret position in line with
+// specified ID.
+// Caret is placed before the space that ends wrapped line,
+// so it stays in the same line.
+func (ta *TextArea) lineEnd(id int) int {
+	line := ta.lines[id]
+	wrapped := id < len(ta.lines)-1 && ta.lines[id+1].start == line.end
+	if wrapped && line.end > line.start && []rune(ta.text)[line.end-1] == ' ' {
+		return line.end - 1
+	}
+	return line.end
+}
+
+// lineCaret returns caret position in line with specified
+// ID closest to specified position from the line start.
+func (ta *TextArea) lineCaret(id int, x float64) int {
+	offsets := ta.lineOffsets(id)
+	pos := 0
+	for i, offset := range offsets[:ta.lineEnd(id)-ta.lines[id].start+1] {
+		if math.Abs(offset-x) < math.Abs(offsets[pos]-x) {
+			pos = i
+		}
+	}
+	return ta.lines[id].start + pos
+}
+
+// lineX returns position of specified caret position
+// from the start of line with specified ID.
+func (ta *TextArea) lineX(id, pos int) float64 {
+	return ta.lineOffsets(id)[pos-ta.lines[id].start]
+}
+
+// lineOffsets returns positions of all runes in line with
+// specified ID from the line start, and position of the
+// line end.
+func (ta *TextArea) lineOffsets(id int) []float64 {
+	atlas := ta.atlas.Atlas()
+	runes := []rune(ta.text)[ta.lines[id].start:ta.lines[id].end]
+	offsets := make([]float64, len(runes)+1)
+	prev := rune(-1)
+	for i, r := range runes {
+		offsets[i+1] = offsets[i] + runeAdvance(atlas, prev, r)
+		prev = r
+	}
+	return offsets
+}
+
+// textOrigin returns position of the first visible line
+// start on the baseline, relative to the text area draw
+// matrix.
+func (ta *TextArea) textOrigin() pixel.Vec {
+	fieldMin := ta.matrix.Unproject(ta.DrawArea().Min)
+	fieldMax := ta.matrix.Unproject(ta.DrawArea().Max)
+	return pixel.V(fieldMin.X, fieldMax.Y-ta.atlas.Atlas().Ascent())
+}
+
+// textWidth returns width available for text lines,
+// relative to the text area draw matrix.
+// Width of scroll buttons is excluded.
+func (ta *TextArea) textWidth() float64 {
+	textMax := ta.DrawArea().Max.Sub(pixel.V(ta.upButton.Size().X, 0))
+	return ta.matrix.Unproject(textMax).X - ta.matrix.Unproject(ta.DrawArea().Min).X
+}
+
+// visibleLines returns number of lines that fit in
+// the text area.
+func (ta *TextArea) visibleLines() int {
+	height := ta.matrix.Unproject(ta.DrawArea().Max).Y - ta.matrix.Unproject(ta.DrawArea().Min).Y
+	return max(1, int(height/ta.atlas.Atlas().LineHeight()))
+}
+
+// updateAtlas adds glyphs for all runes from text area
+// value to the input atlas, and switches input to the
+// latest version of the atlas.
+func (ta *TextArea) updateAtlas() {
+	ta.atlas.AddRunes([]rune(ta.text)...)
+	if ta.atlasVer != ta.atlas.Version() {
+		ta.input = text.New(ta.input.Orig, ta.atlas.Atlas())
+		ta.atlasVer = ta.atlas.Version()
+	}
+}
+
+// Triggered after button up clicked.
+func (ta *TextArea) onButtonUpClicked(b *Button) {
+	ta.scroll(-1)
+}
+
+// Triggered after button down clicked.
+func (ta *TextArea) onButtonDownClicked(b *Button) {
+	ta.scroll(1)
+}
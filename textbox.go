@@ -27,12 +27,18 @@ import (
 	"fmt"
 	"image/color"
 	"strings"
+	"time"
+
+	"github.com/gopxl/beep"
 
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
 )
 
 // Struct for textboxes.
+// Textbox can be used as a log: number of retained texts
+// can be limited, texts can be prefixed with timestamps and
+// colored categories, filtered by category and searched.
 type Textbox struct {
 	bgSize      pixel.Vec
 	color       color.Color
@@ -40,11 +46,41 @@ type Textbox struct {
 	drawArea    pixel.Rect // updated at every draw
 	upButton    *Button
 	downButton  *Button
+	scrollbar   *Scrollbar
+	wheel       wheelScroll
 	textContent []string // every line of text content
 	visibleText []string
 	startID     int
+	textRev     int // increased on each content change
+	shownID     int // start ID of visible text
+	shownRev    int // content revision of visible text
+	visibleIDs  int // number of visible texts
 	buttons     bool
 	focused     bool
+	tr          *translation
+	trRev       int // language revision of translated text
+	reveal      textReveal
+	revealID    int // ID of first revealed text
+	onReveal    func(tb *Textbox)
+	log         []logEntry   // all texts, also texts hidden by the filter
+	lineCache   [][]textLine // lines of texts from content
+	maxLines    int
+	timeFormat  string
+	catColors   map[string]color.Color
+	catFilter   map[string]bool
+	findQuery   string
+	findID      int // ID of the last found text
+	findColor   color.Color
+	shownLines  []textLine // visible lines
+	marked      []bool     // visible lines with found text
+}
+
+// Struct for textbox text with log data.
+type logEntry struct {
+	text     string
+	category string
+	time     time.Time
+	log      bool // added as log text, with prefixes
 }
 
 // NewTextbox creates new textbox with specified
@@ -54,32 +90,73 @@ func NewTextbox(params Params) *Textbox {
 	// Background.
 	t.bgSize = params.SizeRaw
 	t.color = params.MainColor
+	if t.color == nil {
+		t.color = theme.Textbox.MainColor
+	}
 	// Text.
 	textParams := Params{
-		SizeRaw:  pixel.V(t.bgSize.X, 0),
-		FontSize: params.FontSize,
+		SizeRaw:     pixel.V(t.bgSize.X, 0),
+		FontSize:    params.FontSize,
+		Font:        params.Font,
+		Wrap:        params.Wrap,
+		TextEffects: params.TextEffects,
+	}
+	if len(textParams.Font) < 1 {
+		textParams.Font = theme.Textbox.Font
 	}
 	t.textarea = NewText(textParams)
 	t.textarea.Align(AlignLeft)
+	t.shownRev = -1
+	t.reveal = newTextReveal()
+	t.catColors = make(map[string]color.Color)
+	t.findID = -1
+	t.findColor = params.SecColor
+	if t.findColor == nil {
+		t.findColor = theme.Textbox.SecColor
+	}
 	// Buttons.
 	buttonParams := Params{
-		Size:      SizeMini,
-		FontSize:  SizeMedium,
-		Shape:     ShapeSquare,
-		MainColor: params.AccentColor,
+		Size:       theme.ScrollButton.Size,
+		FontSize:   theme.ScrollButton.FontSize,
+		Shape:      ShapeSquare,
+		MainColor:  params.AccentColor,
+		Background: theme.ScrollButton.Background,
+	}
+	if buttonParams.MainColor == nil {
+		buttonParams.MainColor = theme.ScrollButton.MainColor
 	}
 	t.upButton = NewButton(buttonParams)
 	t.upButton.SetOnClickFunc(t.onButtonUpClicked)
 	t.downButton = NewButton(buttonParams)
 	t.downButton.SetOnClickFunc(t.onButtonDownClicked)
+	// Scrollbar.
+	t.scrollbar = NewScrollbar(Params{AccentColor: params.AccentColor})
+	t.scrollbar.SetOnScrollFunc(t.onScroll)
 	return t
 }
 
 // Draw draws textbox.
 func (tb *Textbox) Draw(t pixel.Target, matrix pixel.Matrix) {
+	// Translation.
+	if tb.tr != nil && tb.trRev != langRevision {
+		tb.setTranslation(tb.tr)
+	}
 	// Background.
 	tb.drawArea = MatrixToDrawArea(matrix, tb.Size())
-	DrawRect(t, tb.DrawArea(), pixel.RGBA{0.1, 0.1, 0.1, 0.5})
+	DrawRect(t, tb.DrawArea(), tb.color)
+	// Found lines.
+	if tb.findColor != nil {
+		top := tb.DrawArea().Max.Y
+		for i, l := range tb.shownLines {
+			bottom := top - ConvSize(l.height)
+			line := pixel.R(tb.DrawArea().Min.X, bottom, tb.DrawArea().Max.X, top)
+			line = line.Intersect(tb.DrawArea())
+			if tb.marked[i] && line.Area() > 0 {
+				DrawRect(t, line, tb.findColor)
+			}
+			top = bottom
+		}
+	}
 	// Text content.
 	textareaPos := pixel.V(tb.DrawArea().Min.X, tb.DrawArea().Max.Y-ConvSize(tb.textarea.Size().Y))
 	tb.textarea.Draw(t, Matrix().Moved(textareaPos))
@@ -88,10 +165,11 @@ func (tb *Textbox) Draw(t pixel.Target, matrix pixel.Matrix) {
 	downButtonPos := MoveBR(tb.Size(), tb.downButton.Size())
 	tb.upButton.Draw(t, matrix.Moved(upButtonPos))
 	tb.downButton.Draw(t, matrix.Moved(downButtonPos))
+	drawScrollbar(t, matrix, tb.scrollbar, tb.Size(), tb.upButton.Size())
 }
 
 // Update handles key events.
-func (tb *Textbox) Update(win *Window) {
+func (tb *Textbox) Update(win Input) {
 	// Key events.
 	if tb.Focused() {
 		if win.JustPressed(pixelgl.KeyDown) {
@@ -105,15 +183,54 @@ func (tb *Textbox) Update(win *Window) {
 			}
 		}
 	}
+	// Mouse scroll.
+	if n := tb.wheel.update(win, tb.DrawArea()); n != 0 {
+		tb.scrollbar.SetValue(tb.scrollbar.Value() + n)
+		tb.onScroll(tb.scrollbar)
+	}
+	// Reveal.
+	if tb.reveal.updateInput(win, tb.DrawArea(), tb.Focused()) {
+		tb.revealed()
+	}
 	// Elements.
 	tb.upButton.Update(win)
 	tb.downButton.Update(win)
+	tb.scrollbar.Update(win)
 	tb.updateTextVisibility()
 }
 
+// HandleAction handles specified user action.
+// Scroll actions scroll the text.
+func (tb *Textbox) HandleAction(a Action) bool {
+	switch a {
+	case ActionScrollUp:
+		tb.onButtonUpClicked(tb.upButton)
+		return true
+	case ActionScrollDown:
+		tb.onButtonDownClicked(tb.downButton)
+		return true
+	}
+	return false
+}
+
+// ApplyTheme sets color from textbox style of
+// specified theme.
+func (tb *Textbox) ApplyTheme(t *Theme) {
+	tb.color = t.Textbox.MainColor
+	tb.textarea.ApplyTheme(t)
+	applyScrollButtonTheme(tb.upButton, t, t.Textbox.AccentColor)
+	applyScrollButtonTheme(tb.downButton, t, t.Textbox.AccentColor)
+	tb.scrollbar.ApplyTheme(t)
+	tb.findColor = t.Textbox.SecColor
+	tb.lineCache = nil
+	tb.textRev++
+}
+
 // SetSize sets background size.
 func (tb *Textbox) SetSize(s pixel.Vec) {
 	tb.bgSize = s
+	tb.lineCache = nil
+	tb.textRev++
 }
 
 // Size returns size of textbox background.
@@ -157,28 +274,223 @@ func (tb *Textbox) SetDownButtonBackground(s *pixel.Sprite) {
 	tb.downButton.SetColor(nil)
 }
 
+// SetTextEffects sets specified effects for text
+// content.
+func (tb *Textbox) SetTextEffects(effects TextEffects) {
+	tb.textarea.SetEffects(effects)
+}
+
 // SetMaxTextWidth sets maximal width of single
 // line in text area.
 func (tb *Textbox) SetMaxTextWidth(width float64) {
 	tb.textarea.SetMaxWidth(width)
+	tb.lineCache = nil
+	tb.textRev++
+}
+
+// SetWrap sets specified wrap mode for lines longer
+// than maximal text width.
+func (tb *Textbox) SetWrap(wrap Wrap) {
+	tb.textarea.SetWrap(wrap)
+	tb.lineCache = nil
+	tb.textRev++
 }
 
 // SetText clears textbox and inserts specified
 // lines of text.
 func (tb *Textbox) SetText(text ...string) {
 	tb.Clear()
-	tb.textContent = text
-	tb.startID = len(tb.textContent) - 1
+	now := time.Now()
+	for _, t := range text {
+		tb.log = append(tb.log, logEntry{text: t, time: now})
+	}
+	tb.trimLog()
+	tb.updateContent()
+	tb.startReveal(0)
+}
+
+// SetTextKey clears textbox and inserts text for specified
+// translation key, formatted with specified arguments.
+// Text is updated after each language change.
+func (tb *Textbox) SetTextKey(key string, args ...interface{}) {
+	tb.setTranslation(&translation{key: key, args: args})
 }
 
 // AddText adds specified text to box.
+// Text is added without log prefixes.
 func (tb *Textbox) AddText(text string) {
-	tb.textContent = append(tb.textContent, text)
+	tb.addEntry(logEntry{text: text, time: time.Now()})
+}
+
+// AddLog adds specified text with specified category
+// to box.
+// Text is prefixed with the category name, in category
+// color if set, and with timestamp if timestamp format
+// is set.
+// Empty category adds text without category prefix.
+func (tb *Textbox) AddLog(category, text string) {
+	tb.addEntry(logEntry{
+		text:     text,
+		category: category,
+		time:     time.Now(),
+		log:      true,
+	})
+}
+
+// addEntry adds specified entry to log and to text
+// content, if entry category is shown.
+func (tb *Textbox) addEntry(entry logEntry) {
+	tb.tr = nil
+	tb.log = append(tb.log, entry)
+	shown := tb.categoryShown(entry.category)
+	if shown {
+		tb.textContent = append(tb.textContent, tb.formatLog(entry))
+		tb.lineCache = append(tb.lineCache, nil)
+	}
+	tb.trimLog()
+	tb.textRev++
+	if shown {
+		tb.startReveal(len(tb.textContent) - 1)
+	}
 }
 
 // Clear clears textbox.
 func (tb *Textbox) Clear() {
+	tb.tr = nil
 	tb.textContent = []string{}
+	tb.log = nil
+	tb.lineCache = nil
+	tb.findID = -1
+	tb.textRev++
+	tb.reveal.active = false
+}
+
+// SetMaxLines sets maximal number of texts retained by
+// textbox, oldest texts are removed after adding new ones.
+// Value <= 0 means no limit, default.
+func (tb *Textbox) SetMaxLines(lines int) {
+	tb.maxLines = lines
+	if tb.trimLog() {
+		tb.textRev++
+	}
+}
+
+// SetTimestampFormat sets specified time layout, in
+// format of the time package, as format of timestamps
+// added before log texts, e.g. "15:04:05".
+// Empty format disables timestamps, default.
+func (tb *Textbox) SetTimestampFormat(format string) {
+	tb.timeFormat = format
+	tb.updateContent()
+}
+
+// SetCategoryColor sets specified color as color of
+// prefix of texts with specified category.
+// Nil color removes category color.
+func (tb *Textbox) SetCategoryColor(category string, c color.Color) {
+	if c == nil {
+		delete(tb.catColors, category)
+	} else {
+		tb.catColors[category] = c
+	}
+	tb.updateContent()
+}
+
+// SetCategoryFilter sets specified categories as only
+// categories of displayed texts.
+// Texts without category are always displayed.
+// Empty filter displays texts of all categories.
+func (tb *Textbox) SetCategoryFilter(categories ...string) {
+	tb.catFilter = nil
+	if len(categories) > 0 {
+		tb.catFilter = make(map[string]bool)
+	}
+	for _, c := range categories {
+		tb.catFilter[c] = true
+	}
+	tb.updateContent()
+}
+
+// Find scrolls textbox to the text containing specified
+// query, and highlights all texts containing the query.
+// Texts are searched from the last found text, or from
+// the latest text, to the older texts.
+// Search is case-insensitive and ignores markup.
+// Returns false if no text contains the query.
+// Empty query removes the highlight.
+func (tb *Textbox) Find(query string) bool {
+	if query != tb.findQuery || tb.findID < 0 || tb.findID >= len(tb.textContent) {
+		tb.findID = len(tb.textContent)
+	}
+	tb.findQuery = query
+	tb.textRev++
+	if len(query) < 1 {
+		return false
+	}
+	for i := 1; i <= len(tb.textContent); i++ {
+		id := (tb.findID - i + len(tb.textContent)) % len(tb.textContent)
+		if tb.found(id) {
+			tb.findID = id
+			tb.startID = id
+			return true
+		}
+	}
+	return false
+}
+
+// SetFindColor sets specified color as background color
+// of lines with text found by Find.
+func (tb *Textbox) SetFindColor(c color.Color) {
+	tb.findColor = c
+}
+
+// SetRevealSpeed sets specified number of characters per
+// second as speed of typewriter reveal.
+// Text set or added after this call is revealed character
+// by character on each update, value <= 0 disables reveal.
+// Click on the textbox or skip key press reveals whole
+// text, see SetRevealSkipKeys.
+func (tb *Textbox) SetRevealSpeed(speed float64) {
+	tb.reveal.speed = speed
+	if speed <= 0 {
+		tb.SkipReveal()
+	}
+}
+
+// SetRevealSound sets specified audio as sound played
+// by toolkit audio player for revealed characters.
+// Sound is played at most once per update.
+func (tb *Textbox) SetRevealSound(s *beep.Buffer) {
+	tb.reveal.sound = s
+}
+
+// SetRevealSkipKeys sets specified keys as keys that reveal
+// whole text, by default space and enter.
+// Skip keys work only when text box is focused or under
+// mouse cursor.
+func (tb *Textbox) SetRevealSkipKeys(keys ...pixelgl.Button) {
+	tb.reveal.skipKeys = keys
+}
+
+// SkipReveal reveals whole text.
+func (tb *Textbox) SkipReveal() {
+	if !tb.reveal.active {
+		return
+	}
+	tb.reveal.skip()
+	tb.revealed()
+}
+
+// Revealing checks if text reveal is in progress.
+func (tb *Textbox) Revealing() bool {
+	return tb.reveal.active
+}
+
+// SetOnRevealFinishedFunc sets specified function as
+// function triggered after text reveal is finished or
+// skipped.
+func (tb *Textbox) SetOnRevealFinishedFunc(f func(tb *Textbox)) {
+	tb.onReveal = f
 }
 
 // String returns textbox content.
@@ -204,14 +516,166 @@ func (tb *Textbox) ScrollBottom() {
 	tb.startID = len(tb.textContent) - 1
 }
 
+// setTranslation clears textbox and inserts text from
+// specified translation.
+func (tb *Textbox) setTranslation(tr *translation) {
+	tb.SetText(tr.text())
+	tb.tr = tr
+	tb.trRev = langRevision
+}
+
+// startReveal starts reveal of texts starting from text with
+// specified ID, if reveal is enabled.
+// If reveal is in progress then specified texts are added to
+// the revealed texts.
+func (tb *Textbox) startReveal(id int) {
+	if tb.reveal.speed <= 0 {
+		return
+	}
+	if tb.reveal.active {
+		id = min(id, tb.revealID)
+	}
+	total := 0
+	pauses := make(map[int]int64)
+	for i := id; i < len(tb.textContent); i++ {
+		total += linesReveal(tb.textLines(i), total, pauses)
+	}
+	if !tb.reveal.active {
+		tb.revealID = id
+		tb.reveal.start(total, pauses)
+		return
+	}
+	tb.reveal.total = total
+	tb.reveal.pauses = pauses
+}
+
+// revealed updates text area after change of revealed
+// characters, and triggers reveal finish function if
+// reveal was finished.
+func (tb *Textbox) revealed() {
+	tb.textRev++
+	if !tb.reveal.active && tb.onReveal != nil {
+		tb.onReveal(tb)
+	}
+}
+
+// textLines returns lines of text with specified ID.
+// Lines are cached until the change of text content or
+// text layout.
+func (tb *Textbox) textLines(id int) []textLine {
+	if len(tb.lineCache) != len(tb.textContent) {
+		tb.lineCache = make([][]textLine, len(tb.textContent))
+	}
+	if tb.lineCache[id] != nil {
+		return tb.lineCache[id]
+	}
+	lines := tb.textarea.layout(tb.textContent[id])
+	// Skip empty line after the last new line.
+	if len(lines) > 1 && len(lines[len(lines)-1].spans) < 1 {
+		lines = lines[:len(lines)-1]
+	}
+	tb.lineCache[id] = lines
+	return lines
+}
+
+// updateContent updates text content with all log texts
+// accepted by the category filter, and scrolls textbox to
+// the latest text.
+func (tb *Textbox) updateContent() {
+	tb.textContent = []string{}
+	for _, e := range tb.log {
+		if tb.categoryShown(e.category) {
+			tb.textContent = append(tb.textContent, tb.formatLog(e))
+		}
+	}
+	tb.lineCache = nil
+	tb.findID = -1
+	tb.startID = len(tb.textContent) - 1
+	tb.textRev++
+	tb.reveal.active = false
+}
+
+// trimLog removes the oldest texts above maximal number of
+// texts.
+// Returns true if any text was removed.
+func (tb *Textbox) trimLog() bool {
+	if tb.maxLines <= 0 || len(tb.log) <= tb.maxLines {
+		return false
+	}
+	for _, e := range tb.log[:len(tb.log)-tb.maxLines] {
+		if !tb.categoryShown(e.category) || len(tb.textContent) < 1 {
+			continue
+		}
+		tb.textContent = tb.textContent[1:]
+		if len(tb.lineCache) > 0 {
+			tb.lineCache = tb.lineCache[1:]
+		}
+		tb.startID = max(0, tb.startID-1)
+		tb.revealID = max(0, tb.revealID-1)
+		tb.findID--
+	}
+	tb.log = append([]logEntry{}, tb.log[len(tb.log)-tb.maxLines:]...)
+	return true
+}
+
+// formatLog returns text of specified log entry with
+// timestamp and category prefixes.
+// Texts not added as log texts are returned without
+// prefixes.
+func (tb *Textbox) formatLog(e logEntry) string {
+	if !e.log {
+		return e.text
+	}
+	prefix := ""
+	if len(tb.timeFormat) > 0 {
+		prefix = EscapeMarkup("["+e.time.Format(tb.timeFormat)+"]") + " "
+	}
+	if len(e.category) > 0 {
+		category := EscapeMarkup("[" + e.category + "]")
+		if c, ok := tb.catColors[e.category]; ok {
+			category = "[color=" + colorMarkup(c) + "]" + category + "[/color]"
+		}
+		prefix += category + " "
+	}
+	return prefix + e.text
+}
+
+// categoryShown checks if texts with specified category
+// are accepted by the category filter.
+func (tb *Textbox) categoryShown(category string) bool {
+	return tb.catFilter == nil || len(category) < 1 || tb.catFilter[category]
+}
+
+// found checks if text with specified ID contains current
+// find query.
+func (tb *Textbox) found(id int) bool {
+	if len(tb.findQuery) < 1 {
+		return false
+	}
+	text := strings.ToLower(StripMarkup(tb.textContent[id]))
+	return strings.Contains(text, strings.ToLower(tb.findQuery))
+}
+
 // updateTextVisibility updates conte nt of visible
 // text area.
+// Text area is updated only after scrolling or content
+// change.
 func (tb *Textbox) updateTextVisibility() {
+	if tb.shownID == tb.startID && tb.shownRev == tb.textRev {
+		return
+	}
+	tb.shownID = tb.startID
+	tb.shownRev = tb.textRev
 	var (
-		visibleText       []string
+		visibleText       []textLine
 		visibleTextHeight float64
+		shown             = -1 // number of shown characters
+		visibleIDs        = 0
+		marked            []bool
 	)
-	boxWidth := tb.Size().X
+	if tb.reveal.active {
+		shown = 0
+	}
 	for i := len(tb.textContent) - 1; i >= 0; i-- {
 		if i > tb.startID {
 			continue
@@ -219,60 +683,47 @@ func (tb *Textbox) updateTextVisibility() {
 		if visibleTextHeight >= tb.Size().Y {
 			break
 		}
-		line := tb.textContent[i]
-		breakLines := tb.breakLine(line, boxWidth)
-		for j := len(breakLines) - 1; j >= 0; j-- { // reverse order
-			bl := breakLines[j]
-			visibleText = append(visibleText, bl)
-			visibleTextHeight += tb.textarea.BoundsOf(bl).H()
-			if visibleTextHeight >= tb.Size().Y {
-				break
+		lines := tb.textLines(i)
+		found := tb.found(i)
+		visibleIDs++
+		revealed := 0 // revealed characters of the text
+		if tb.reveal.active && i >= tb.revealID {
+			offset := 0
+			for j := tb.revealID; j < i; j++ {
+				offset += linesRunes(tb.textLines(j))
 			}
+			revealed = int(tb.reveal.shown) - offset
 		}
-	}
-	tb.textarea.Clear()
-	for i := len(visibleText) - 1; i >= 0; i-- {
-		txt := visibleText[i]
-		fmt.Fprintf(tb.textarea, txt)
-	}
-}
-
-// breakLine breaks specified line into few lines with specified
-// maximal width.
-func (t *Textbox) breakLine(line string, width float64) []string {
-	lines := make([]string, 0)
-	lineWidth := t.textarea.BoundsOf(line).W()
-	if width > 0 && lineWidth > width {
-		breakPoint := t.breakPoint(line, width)
-		breakLines := SplitSubN(line, breakPoint)
-		for i, l := range breakLines {
-			if !strings.HasSuffix(l, "\n") {
-				breakLines[i] += "\n"
+		for j := len(lines) - 1; j >= 0; j-- { // reverse order
+			l := lines[j]
+			visibleText = append(visibleText, l)
+			marked = append(marked, found)
+			visibleTextHeight += l.height
+			if shown >= 0 {
+				chars := linesRunes(lines[j : j+1])
+				if i >= tb.revealID {
+					chars = max(0, min(chars, revealed-linesRunes(lines[:j])))
+				}
+				shown += chars
+			}
+			if visibleTextHeight >= tb.Size().Y {
+				break
 			}
 		}
-		lines = append(lines, breakLines...)
-	} else {
-		lines = append(lines, line)
 	}
-	return lines
-}
-
-// breakPoint return break position for specified line and width.
-func (t *Textbox) breakPoint(line string, width float64) int {
-	checkLine := ""
-	breakPoint := -1
-	for _, c := range line {
-		if c == '\n' {
-			checkLine = ""
-			breakPoint = -1
-		}
-		checkLine += string(c)
-		breakPoint++
-		if t.textarea.BoundsOf(checkLine).W() >= width {
-			return breakPoint
-		}
+	// Reverse lines to the draw order.
+	for i, j := 0, len(visibleText)-1; i < j; i, j = i+1, j-1 {
+		visibleText[i], visibleText[j] = visibleText[j], visibleText[i]
+		marked[i], marked[j] = marked[j], marked[i]
 	}
-	return len(line)
+	tb.shownLines = visibleText
+	tb.marked = marked
+	tb.textarea.shown = shown
+	tb.textarea.setLines(visibleText)
+	// Scrollbar.
+	tb.visibleIDs = max(1, visibleIDs)
+	tb.scrollbar.SetContent(len(tb.textContent), tb.visibleIDs)
+	tb.scrollbar.SetValue(tb.startID - tb.visibleIDs + 1)
 }
 
 // Triggered after button up clicked.
@@ -290,3 +741,8 @@ func (tb *Textbox) onButtonDownClicked(b *Button) {
 	}
 	tb.startID++
 }
+
+// Triggered after scrollbar value was changed.
+func (tb *Textbox) onScroll(s *Scrollbar) {
+	tb.startID = max(0, min(s.Value()+tb.visibleIDs-1, len(tb.textContent)-1))
+}
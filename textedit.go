@@ -25,8 +25,8 @@ package mtk
 
 import (
 	"image/color"
-
-	"golang.org/x/image/colornames"
+	"math"
+	"unicode"
 
 	"github.com/gopxl/pixel"
 	"github.com/gopxl/pixel/pixelgl"
@@ -34,15 +34,40 @@ import (
 )
 
 // Struct for text edit fields.
+// Text edit supports caret movement with arrow, Home and
+// End keys or mouse, text selection with Shift or mouse
+// drag, clipboard shortcuts(Ctrl+C/X/V) and undo/redo
+// shortcuts(Ctrl+Z/Y).
+// Typed and pasted text can be constrained with maximal
+// length and rune filters, text value can be checked with
+// validator function.
+// Completion suggestions can be displayed under the field
+// and chosen with Up/Down/Tab and Enter keys or mouse.
 type Textedit struct {
+	textEditor
 	size       pixel.Vec
 	drawArea   pixel.Rect
+	matrix     pixel.Matrix // updated on each draw
 	color      color.Color
 	colorFocus color.Color
+	colorSel   color.Color
+	colorErr   color.Color
 	input      *text.Text
-	text       string
+	hint       *text.Text // placeholder text
+	info       *InfoWindow
+	atlas      *DynamicAtlas
+	atlasVer   int // version of atlas used by input
+	hintText   string
+	mask       rune // rune displayed instead of text runes
+	validator  func(text string) error
+	err        error   // validation error
+	scroll     float64 // horizontal offset of visible text
+	dragging   bool
+	hovered    bool
 	focused    bool
 	disabled   bool
+	suggest    suggestionList
+	overlay    bool // suggestions drawn with DrawPopup
 }
 
 // NewTextedit creates new textedit based on
@@ -51,56 +76,159 @@ func NewTextedit(params Params) *Textedit {
 	t := new(Textedit)
 	// Background.
 	t.color = params.MainColor
-	t.colorFocus = colornames.Crimson
+	if t.color == nil {
+		t.color = theme.Textedit.MainColor
+	}
+	t.colorFocus = theme.Textedit.FocusedColor
+	t.colorSel = params.AccentColor
+	if t.colorSel == nil {
+		t.colorSel = theme.Textedit.AccentColor
+	}
+	t.colorErr = theme.Textedit.ErrorColor
 	// Text input.
 	t.size = params.SizeRaw
-	font := MainFont(params.FontSize)
-	atlas := Atlas(&font)
-	t.input = text.New(pixel.V(0, 0), atlas)
+	font := params.Font
+	if len(font) < 1 {
+		font = theme.Textedit.Font
+	}
+	t.atlas = FontAtlas(font, params.FontSize)
+	t.atlasVer = t.atlas.Version()
+	t.input = text.New(pixel.V(0, 0), t.atlas.Atlas())
+	t.hint = text.New(pixel.V(0, 0), t.atlas.Atlas())
+	t.matrix = pixel.IM
+	t.textEditor = newTextEditor(false)
+	t.onChange = t.onTextChange
+	t.onMove = t.onCaretMove
+	t.onEdit = t.onTextEdit
+	t.suggest = newSuggestionList()
+	// Info window.
+	infoParams := Params{
+		FontSize:  theme.InfoWindow.FontSize,
+		Font:      theme.InfoWindow.Font,
+		MainColor: theme.InfoWindow.SecColor,
+	}
+	t.info = NewInfoWindow(infoParams)
 	return t
 }
 
 // Draw draws text edit.
+// Suggestion list is drawn on top of the text edit, but
+// elements drawn after the text edit cover the list, see
+// SetPopupOverlay.
 func (te *Textedit) Draw(t pixel.Target, matrix pixel.Matrix) {
 	// Draw area.
 	te.drawArea = MatrixToDrawArea(matrix, te.Size())
+	te.matrix = matrix
 	color := te.color
 	if te.Focused() {
 		color = te.colorFocus
 	}
 	DrawRect(t, te.DrawArea(), color)
+	if te.err != nil && te.colorErr != nil {
+		DrawRectBorder(t, te.DrawArea(), te.colorErr, ConvSize(2))
+	}
+	// Selection.
+	origin := te.textOrigin()
+	atlas := te.atlas.Atlas()
+	start, end := te.Selection()
+	if te.Focused() && start != end && te.colorSel != nil {
+		selMin := matrix.Project(origin.Add(pixel.V(te.runeX(start), -atlas.Descent())))
+		selMax := matrix.Project(origin.Add(pixel.V(te.runeX(end), atlas.Ascent())))
+		sel := pixel.R(selMin.X, selMin.Y, selMax.X, selMax.Y).Intersect(te.DrawArea())
+		if sel.Area() > 0 {
+			DrawRect(t, sel, te.colorSel)
+		}
+	}
 	// Text input.
-	inputMove := pixel.V(-te.Size().X/2, 0)
-	te.input.Draw(t, matrix.Moved(inputMove))
+	if te.input.Bounds().Area() > 0 {
+		inputMatrix := pixel.IM.Moved(te.input.Bounds().Min.Add(origin))
+		te.input.Draw(t, inputMatrix.Chained(matrix))
+	}
+	if len(te.text) < 1 && te.hint.Bounds().Area() > 0 {
+		hintMatrix := pixel.IM.Moved(te.hint.Bounds().Min.Add(origin))
+		te.hint.Draw(t, hintMatrix.Chained(matrix))
+	}
+	// Caret.
+	if te.Focused() && (te.blink/caretBlink)%2 == 0 {
+		caretMin := matrix.Project(origin.Add(pixel.V(te.runeX(te.caret), -atlas.Descent())))
+		caretMax := matrix.Project(origin.Add(pixel.V(te.runeX(te.caret), atlas.Ascent())))
+		caret := pixel.R(caretMin.X, caretMin.Y, caretMin.X+math.Max(1, ConvSize(2)), caretMax.Y)
+		if te.DrawArea().Contains(caret.Min) {
+			DrawRect(t, caret, te.input.Color)
+		}
+	}
+	// Suggestions.
+	if !te.overlay {
+		te.DrawPopup(t)
+	}
+	// Info window.
+	if te.err != nil && te.hovered {
+		te.info.Draw(t)
+	}
 }
 
 // Update updates text edit.
-func (te *Textedit) Update(win *Window) {
+func (te *Textedit) Update(win Input) {
 	if te.Disabled() {
 		return
 	}
+	te.blink += win.Delta()
+	// On-hover.
+	te.hovered = te.DrawArea().Contains(win.MousePosition())
+	if te.hovered && te.err != nil {
+		te.info.Update(win)
+	}
+	// Suggestions.
+	if te.focused {
+		if s, ok := te.suggest.updateInput(win); ok {
+			te.complete(s)
+			return
+		}
+	}
+	// Mouse events.
 	if win.JustPressed(pixelgl.MouseButtonLeft) {
 		if te.DrawArea().Contains(win.MousePosition()) {
 			te.Focus(true)
+			te.dragging = true
+			te.moveCaret(te.mouseCaret(win.MousePosition()), shiftPressed(win))
 		} else {
 			te.Focus(false)
 		}
 	}
+	if te.dragging && win.Pressed(pixelgl.MouseButtonLeft) {
+		te.moveCaret(te.mouseCaret(win.MousePosition()), true)
+	}
+	if win.JustReleased(pixelgl.MouseButtonLeft) {
+		te.dragging = false
+	}
+	// Key events.
 	if te.focused {
-		if win.JustPressed(pixelgl.KeyBackspace) {
-			if len(te.text) > 0 {
-				te.SetText(te.text[:len(te.text)-1])
-			}
+		te.updateKeys(win)
+		if typed := win.Typed(); len(typed) > 0 {
+			te.insert(typed, editTyping)
 		}
-		te.SetText(te.text + win.Typed())
 	}
-	te.input.Clear()
-	te.input.WriteString(te.text)
+	te.updateScroll()
+	te.updateInput()
+}
+
+// ApplyTheme sets colors from text edit style of
+// specified theme.
+func (te *Textedit) ApplyTheme(t *Theme) {
+	te.color = t.Textedit.MainColor
+	te.colorFocus = t.Textedit.FocusedColor
+	te.colorSel = t.Textedit.AccentColor
+	te.colorErr = t.Textedit.ErrorColor
+	te.info.applyElementTheme(t)
 }
 
 // Focus sets or removes focus from text edit.
 func (te *Textedit) Focus(focus bool) {
 	te.focused = focus
+	te.blink = 0
+	if !focus {
+		te.suggest.hide()
+	}
 }
 
 // Focused checks whether text edit is focused.
@@ -118,20 +246,104 @@ func (te *Textedit) Disabled() bool {
 	return te.disabled
 }
 
-// Clear clears text edit input.
-func (te *Textedit) Clear() {
-	te.text = ""
+// SetPlaceholder sets specified text as placeholder
+// displayed when text edit is empty.
+func (te *Textedit) SetPlaceholder(text string) {
+	te.hintText = text
+	te.updateAtlas()
+}
+
+// SetPasswordMask sets specified rune as rune displayed
+// instead of each rune of text edit value, e.g. '*'.
+// Copying text from text edit with mask is disabled.
+// Mask 0 disables masking.
+func (te *Textedit) SetPasswordMask(mask rune) {
+	te.mask = mask
+	te.secret = mask != 0
+	te.updateAtlas()
+	te.updateInput()
+}
+
+// SetMaxLength sets maximal number of runes that can be
+// typed or pasted to text edit, 0 means no limit.
+func (te *Textedit) SetMaxLength(length int) {
+	te.maxLength = length
+}
+
+// SetFilters sets specified functions as rune filters for
+// text typed or pasted to text edit.
+// Rune is accepted if it is accepted by any filter, all
+// runes are accepted if there are no filters.
+func (te *Textedit) SetFilters(filters ...func(r rune) bool) {
+	te.filters = filters
+}
+
+// SetValidator sets specified function as validator
+// of text edit value.
+// Validator is called after each value change, text edit
+// with value rejected by validator is marked with error
+// color, and validator error message is displayed in
+// info window while text edit is hovered.
+func (te *Textedit) SetValidator(validator func(text string) error) {
+	te.validator = validator
+	te.validate()
 }
 
-// Text return current value of text edit.
-func (te *Textedit) Text() string {
-	return te.text
+// Valid checks if current value was accepted by
+// the validator.
+func (te *Textedit) Valid() bool {
+	return te.err == nil
 }
 
-// SetText sets specified text as current
-// value of text edit field.
-func (te *Textedit) SetText(text string) {
-	te.text = text
+// ValidationError returns error returned by the validator
+// for current value, or nil if value is valid.
+func (te *Textedit) ValidationError() error {
+	return te.err
+}
+
+// SetSuggestionProvider sets specified function as
+// provider of completion suggestions for text before
+// the caret.
+// Suggestions are displayed under the text edit after each
+// typed or removed rune, chosen suggestion replaces the
+// text before the caret.
+// Nil provider disables suggestions.
+func (te *Textedit) SetSuggestionProvider(provider func(prefix string) []string) {
+	te.suggest.provider = provider
+	te.suggest.hide()
+}
+
+// SetPopupOverlay sets whether suggestion list is drawn
+// separately with DrawPopup, instead of with Draw.
+// Overlay should be used if other elements are drawn after
+// the text edit, e.g. in layouts, and DrawPopup should be
+// called after drawing all elements.
+func (te *Textedit) SetPopupOverlay(overlay bool) {
+	te.overlay = overlay
+}
+
+// DrawPopup draws suggestion list under the text edit,
+// if text edit is focused.
+func (te *Textedit) DrawPopup(t pixel.Target) {
+	if te.Focused() {
+		te.suggest.draw(t, te.DrawArea())
+	}
+}
+
+// PopupOpen checks if suggestion list is displayed.
+// Focus manager does not move focus while suggestion
+// list is displayed, so Tab and arrow keys can be used
+// to choose suggestions.
+func (te *Textedit) PopupOpen() bool {
+	return te.Focused() && te.suggest.visible()
+}
+
+// UsesArrowKeys checks whether text edit uses arrow keys
+// to move the caret, i.e. is focused.
+// Focus manager does not move focus with arrow keys while
+// text edit is focused.
+func (te *Textedit) UsesArrowKeys() bool {
+	return te.Focused()
 }
 
 // SetSize sets text edit size.
@@ -148,3 +360,177 @@ func (te *Textedit) Size() pixel.Vec {
 func (te *Textedit) DrawArea() pixel.Rect {
 	return te.drawArea
 }
+
+// complete replaces text before the caret with specified
+// suggestion.
+func (te *Textedit) complete(suggestion string) {
+	te.replace(0, te.caret, suggestion, editPaste)
+}
+
+// onTextChange updates input text and validation
+// error after text edit value change.
+func (te *Textedit) onTextChange() {
+	te.updateAtlas()
+	te.updateInput()
+	te.validate()
+}
+
+// onCaretMove hides suggestions and updates text scroll
+// after caret move.
+func (te *Textedit) onCaretMove() {
+	te.suggest.hide()
+	te.updateScroll()
+}
+
+// onTextEdit refreshes suggestions after typing or
+// removing text.
+func (te *Textedit) onTextEdit(kind editKind) {
+	if kind == editTyping || kind == editDelete {
+		te.suggest.refresh(string([]rune(te.text)[:te.caret]), te.Size(),
+			te.color, te.colorFocus)
+	}
+}
+
+// validate checks current value with validator and updates
+// info window with validation error message.
+func (te *Textedit) validate() {
+	te.err = nil
+	if te.validator != nil {
+		te.err = te.validator(te.text)
+	}
+	if te.err != nil {
+		te.info.SetText(EscapeMarkup(te.err.Error()))
+	}
+}
+
+// mouseCaret returns caret position closest to specified
+// mouse position.
+func (te *Textedit) mouseCaret(mousePos pixel.Vec) int {
+	x := te.matrix.Unproject(mousePos).X - te.textOrigin().X
+	offsets := te.runeOffsets()
+	pos := 0
+	for i, offset := range offsets {
+		if math.Abs(offset-x) < math.Abs(offsets[pos]-x) {
+			pos = i
+		}
+	}
+	return pos
+}
+
+// updateScroll updates horizontal scroll of text, so
+// caret is always visible.
+func (te *Textedit) updateScroll() {
+	width := te.textWidth()
+	if width <= 0 {
+		return
+	}
+	caretX := te.runeX(te.caret)
+	switch {
+	case caretX-te.scroll > width:
+		te.scroll = caretX - width
+	case caretX < te.scroll:
+		te.scroll = caretX
+	}
+	textX := te.runeX(len([]rune(te.text)))
+	te.scroll = math.Max(0, math.Min(te.scroll, textX-width))
+}
+
+// updateInput writes visible part of text edit value to
+// the input text.
+func (te *Textedit) updateInput() {
+	te.input.Clear()
+	runes := te.displayRunes()
+	offsets := te.runeOffsets()
+	width := te.textWidth()
+	start, end := 0, len(runes)
+	for start < end && offsets[start] < te.scroll {
+		start++
+	}
+	for width > 0 && end > start && offsets[end]-te.scroll > width {
+		end--
+	}
+	te.input.Dot = pixel.V(offsets[start], 0)
+	te.input.WriteString(string(runes[start:end]))
+}
+
+// textOrigin returns position of the text start on the
+// baseline, relative to the text edit draw matrix.
+// Text is centered vertically, and moved with horizontal
+// scroll.
+func (te *Textedit) textOrigin() pixel.Vec {
+	atlas := te.atlas.Atlas()
+	fieldMin := te.matrix.Unproject(te.DrawArea().Min)
+	fieldMax := te.matrix.Unproject(te.DrawArea().Max)
+	baseline := (fieldMin.Y+fieldMax.Y)/2 - (atlas.Ascent()-atlas.Descent())/2
+	return pixel.V(fieldMin.X-te.scroll, baseline)
+}
+
+// textWidth returns width of text area, relative to
+// the text edit draw matrix.
+func (te *Textedit) textWidth() float64 {
+	return te.matrix.Unproject(te.DrawArea().Max).X - te.matrix.Unproject(te.DrawArea().Min).X
+}
+
+// runeX returns position of rune with specified index
+// from the text start.
+func (te *Textedit) runeX(id int) float64 {
+	return te.runeOffsets()[id]
+}
+
+// runeOffsets returns positions of all runes from the
+// text start, and position of the text end.
+func (te *Textedit) runeOffsets() []float64 {
+	atlas := te.atlas.Atlas()
+	runes := te.displayRunes()
+	offsets := make([]float64, len(runes)+1)
+	prev := rune(-1)
+	for i, r := range runes {
+		offsets[i+1] = offsets[i] + runeAdvance(atlas, prev, r)
+		prev = r
+	}
+	return offsets
+}
+
+// displayRunes returns runes displayed in text edit,
+// text edit value or mask runes.
+func (te *Textedit) displayRunes() []rune {
+	runes := []rune(te.text)
+	if te.mask == 0 {
+		return runes
+	}
+	for i := range runes {
+		runes[i] = te.mask
+	}
+	return runes
+}
+
+// updateAtlas adds glyphs for all runes from text edit
+// value, placeholder and mask to the input atlas, switches
+// input to the latest version of the atlas and writes
+// placeholder text.
+func (te *Textedit) updateAtlas() {
+	te.atlas.AddRunes([]rune(te.text + te.hintText)...)
+	if te.mask != 0 {
+		te.atlas.AddRunes(te.mask)
+	}
+	if te.atlasVer != te.atlas.Version() {
+		te.input = text.New(te.input.Orig, te.atlas.Atlas())
+		te.hint = text.New(te.hint.Orig, te.atlas.Atlas())
+		te.atlasVer = te.atlas.Version()
+	}
+	te.hint.Clear()
+	te.hint.Color = pixel.ToRGBA(te.input.Color).Scaled(0.5)
+	te.hint.WriteString(te.hintText)
+}
+
+// FilterDigits is rune filter for text edit, that
+// accepts only digits.
+func FilterDigits(r rune) bool {
+	return unicode.IsDigit(r)
+}
+
+// FilterAlphanumeric is rune filter for text edit, that
+// accepts only letters and digits.
+func FilterAlphanumeric(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
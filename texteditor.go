@@ -0,0 +1,341 @@
+/*
+ * texteditor.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"strings"
+
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for text editor, editing core of text edit and
+// text area.
+// Editor stores text value with caret, selection and edit
+// history, and handles caret movement, removing, clipboard
+// and undo/redo keys.
+// Widgets are notified about changes with callback
+// functions.
+type textEditor struct {
+	text      string
+	caret     int   // caret position in runes
+	anchor    int   // selection start, equal to caret if nothing is selected
+	blink     int64 // time from last caret move in millis
+	multiline bool
+	secret    bool // copying disabled
+	maxLength int
+	filters   []func(r rune) bool
+	history   editHistory
+	onChange  func()              // called after text change
+	onMove    func()              // called after caret move
+	onEdit    func(kind editKind) // called after edit
+}
+
+const (
+	// Caret blink interval in milliseconds.
+	caretBlink = 500
+	// Default number of stored undo steps.
+	defaultUndoDepth = 100
+)
+
+// newTextEditor creates new text editor, new lines are
+// replaced with spaces if editor is not multi-line.
+func newTextEditor(multiline bool) textEditor {
+	return textEditor{
+		multiline: multiline,
+		history:   newEditHistory(defaultUndoDepth),
+	}
+}
+
+// Clear clears text value.
+func (e *textEditor) Clear() {
+	e.SetText("")
+}
+
+// Text returns current text value.
+func (e *textEditor) Text() string {
+	return e.text
+}
+
+// SetText sets specified text as current text value.
+// Caret is moved to the end of the text.
+// Change can be reverted with Undo.
+func (e *textEditor) SetText(text string) {
+	text = e.normalize(text)
+	if text == e.text {
+		return
+	}
+	e.history.record(e.state(), editSet, text, len([]rune(text)))
+	e.setText(text)
+}
+
+// Undo reverts the last edit step.
+func (e *textEditor) Undo() {
+	if state, ok := e.history.undoState(e.state()); ok {
+		e.setState(state)
+	}
+}
+
+// Redo restores the last edit step reverted with Undo.
+func (e *textEditor) Redo() {
+	if state, ok := e.history.redoState(e.state()); ok {
+		e.setState(state)
+	}
+}
+
+// CanUndo checks if there is any edit step to undo.
+func (e *textEditor) CanUndo() bool {
+	return len(e.history.undo) > 0
+}
+
+// CanRedo checks if there is any edit step to redo.
+func (e *textEditor) CanRedo() bool {
+	return len(e.history.redo) > 0
+}
+
+// SetUndoDepth sets maximal number of edit steps that
+// can be reverted with Undo, 100 by default.
+func (e *textEditor) SetUndoDepth(depth int) {
+	e.history.setDepth(depth)
+}
+
+// Caret returns caret position, as number of runes
+// before the caret.
+func (e *textEditor) Caret() int {
+	return e.caret
+}
+
+// SetCaret moves caret to specified position, as number
+// of runes before the caret, and removes selection.
+func (e *textEditor) SetCaret(pos int) {
+	e.moveCaret(pos, false)
+}
+
+// Select selects text between specified positions, as
+// numbers of runes before the selection start and end.
+// Caret is moved to the selection end.
+func (e *textEditor) Select(start, end int) {
+	e.moveCaret(start, false)
+	e.moveCaret(end, true)
+}
+
+// Selection returns positions of start and end of
+// selected text, as numbers of runes before the selection
+// start and end.
+// Start is equal to end if no text is selected.
+func (e *textEditor) Selection() (start, end int) {
+	return min(e.anchor, e.caret), max(e.anchor, e.caret)
+}
+
+// SelectedText returns selected text.
+func (e *textEditor) SelectedText() string {
+	start, end := e.Selection()
+	return string([]rune(e.text)[start:end])
+}
+
+// updateKeys handles caret movement, removing and
+// clipboard keys pressed or repeated in the current
+// frame.
+// Home and End keys move caret to the text start and end.
+func (e *textEditor) updateKeys(win Input) {
+	shift := shiftPressed(win)
+	start, end := e.Selection()
+	switch {
+	case keyPressed(win, pixelgl.KeyLeft) && start != end && !shift:
+		e.moveCaret(start, false)
+	case keyPressed(win, pixelgl.KeyLeft):
+		e.moveCaret(e.caret-1, shift)
+	case keyPressed(win, pixelgl.KeyRight) && start != end && !shift:
+		e.moveCaret(end, false)
+	case keyPressed(win, pixelgl.KeyRight):
+		e.moveCaret(e.caret+1, shift)
+	case keyPressed(win, pixelgl.KeyHome):
+		e.moveCaret(0, shift)
+	case keyPressed(win, pixelgl.KeyEnd):
+		e.moveCaret(len([]rune(e.text)), shift)
+	case keyPressed(win, pixelgl.KeyBackspace) && start == end:
+		e.replace(e.caret-1, e.caret, "", editDelete)
+	case keyPressed(win, pixelgl.KeyDelete) && start == end:
+		e.replace(e.caret, e.caret+1, "", editDelete)
+	case keyPressed(win, pixelgl.KeyBackspace), keyPressed(win, pixelgl.KeyDelete):
+		e.insert("", editDelete)
+	}
+	if !ctrlPressed(win) {
+		return
+	}
+	switch {
+	case keyPressed(win, pixelgl.KeyZ) && shift, keyPressed(win, pixelgl.KeyY):
+		e.Redo()
+	case keyPressed(win, pixelgl.KeyZ):
+		e.Undo()
+	case win.JustPressed(pixelgl.KeyA):
+		e.Select(0, len([]rune(e.text)))
+	case win.JustPressed(pixelgl.KeyC) && start != end && !e.secret:
+		if cb, ok := win.(ClipboardInput); ok {
+			cb.SetClipboard(e.SelectedText())
+		}
+	case win.JustPressed(pixelgl.KeyX) && start != end && !e.secret:
+		if cb, ok := win.(ClipboardInput); ok {
+			cb.SetClipboard(e.SelectedText())
+			e.insert("", editDelete)
+		}
+	case keyPressed(win, pixelgl.KeyV):
+		if cb, ok := win.(ClipboardInput); ok {
+			e.insert(cb.Clipboard(), editPaste)
+		}
+	}
+}
+
+// insert replaces selected text with specified text, as
+// edit of specified kind.
+func (e *textEditor) insert(s string, kind editKind) {
+	start, end := e.Selection()
+	e.replace(start, end, s, kind)
+}
+
+// replace replaces text between specified positions with
+// specified text, as edit of specified kind.
+// Typed and pasted text is constrained by rune filters and
+// maximal length.
+// Caret is moved to the end of inserted text.
+func (e *textEditor) replace(start, end int, s string, kind editKind) {
+	runes := []rune(e.text)
+	start = max(0, min(start, len(runes)))
+	end = max(start, min(end, len(runes)))
+	s = e.normalize(s)
+	if kind == editTyping || kind == editPaste {
+		s = e.constrain(s, len(runes)-(end-start))
+	}
+	if start == end && len(s) < 1 {
+		return
+	}
+	caret := start + len([]rune(s))
+	e.history.record(e.state(), kind, s, caret)
+	e.setText(string(runes[:start]) + s + string(runes[end:]))
+	e.moveCaret(caret, false)
+	if e.onEdit != nil {
+		e.onEdit(kind)
+	}
+}
+
+// constrain returns specified text without runes rejected
+// by rune filters, and truncated to maximal length of text
+// value with specified length.
+func (e *textEditor) constrain(s string, length int) string {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		if e.accepts(r) {
+			runes = append(runes, r)
+		}
+	}
+	if e.maxLength > 0 && length+len(runes) > e.maxLength {
+		runes = runes[:max(0, e.maxLength-length)]
+	}
+	return string(runes)
+}
+
+// accepts checks if specified rune is accepted by any
+// rune filter.
+func (e *textEditor) accepts(r rune) bool {
+	if len(e.filters) < 1 {
+		return true
+	}
+	for _, f := range e.filters {
+		if f(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize returns specified text with normalized line
+// endings, new lines are replaced with spaces if editor
+// is not multi-line.
+func (e *textEditor) normalize(s string) string {
+	s = normalizeLines(s)
+	if !e.multiline {
+		s = strings.ReplaceAll(s, "\n", " ")
+	}
+	return s
+}
+
+// setText sets specified text as current text value, and
+// moves caret to the end of the text.
+func (e *textEditor) setText(text string) {
+	e.text = text
+	e.caret = len([]rune(text))
+	e.anchor = e.caret
+	if e.onChange != nil {
+		e.onChange()
+	}
+	e.moveCaret(e.caret, false)
+}
+
+// state returns current state of editor.
+func (e *textEditor) state() editState {
+	return editState{e.text, e.caret, e.anchor}
+}
+
+// setState restores specified state of editor.
+func (e *textEditor) setState(state editState) {
+	e.setText(state.text)
+	e.moveCaret(state.anchor, false)
+	e.moveCaret(state.caret, true)
+}
+
+// moveCaret moves caret to specified position, selection
+// is extended to the new caret position if select is true,
+// otherwise selection is removed.
+func (e *textEditor) moveCaret(pos int, selection bool) {
+	e.caret = max(0, min(pos, len([]rune(e.text))))
+	if !selection {
+		e.anchor = e.caret
+	}
+	e.blink = 0
+	if e.onMove != nil {
+		e.onMove()
+	}
+}
+
+// normalizeLines returns specified text with Windows and
+// old Mac line endings replaced with new lines, and tabs
+// replaced with spaces.
+func normalizeLines(s string) string {
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", "    ").Replace(s)
+}
+
+// keyPressed checks if specified key was pressed or
+// repeated in the current frame.
+func keyPressed(win Input, key pixelgl.Button) bool {
+	return win.JustPressed(key) || win.Repeated(key)
+}
+
+// shiftPressed checks if any shift key is pressed.
+func shiftPressed(win Input) bool {
+	return win.Pressed(pixelgl.KeyLeftShift) || win.Pressed(pixelgl.KeyRightShift)
+}
+
+// ctrlPressed checks if any control key is pressed.
+func ctrlPressed(win Input) bool {
+	return win.Pressed(pixelgl.KeyLeftControl) || win.Pressed(pixelgl.KeyRightControl)
+}
@@ -0,0 +1,74 @@
+/*
+ * texteffects.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for text effects, like outline, drop shadow and
+// color gradient.
+// Effect is disabled if its color is nil.
+type TextEffects struct {
+	OutlineColor     color.Color
+	OutlineThickness float64 // in pixels for 1080p
+	ShadowColor      color.Color
+	ShadowOffset     pixel.Vec // in pixels for 1080p
+	Gradient         Gradient
+	GradientStart    color.Color
+	GradientEnd      color.Color
+}
+
+// Directions of outline copies of text.
+var outlineDirections = []pixel.Vec{
+	pixel.V(1, 0), pixel.V(1, 1), pixel.V(0, 1), pixel.V(-1, 1),
+	pixel.V(-1, 0), pixel.V(-1, -1), pixel.V(0, -1), pixel.V(1, -1),
+}
+
+// outline checks if outline effect is enabled.
+func (e TextEffects) outline() bool {
+	return e.OutlineColor != nil && e.OutlineThickness > 0
+}
+
+// shadow checks if drop shadow effect is enabled.
+func (e TextEffects) shadow() bool {
+	return e.ShadowColor != nil
+}
+
+// gradient checks if gradient effect is enabled.
+func (e TextEffects) gradient() bool {
+	return e.Gradient != GradientNone && e.GradientStart != nil && e.GradientEnd != nil
+}
+
+// gradientColor returns gradient color at specified
+// position, from 0(start) to 1(end).
+func (e TextEffects) gradientColor(pos float64) color.Color {
+	pos = math.Max(0, math.Min(1, pos))
+	start := pixel.ToRGBA(e.GradientStart)
+	end := pixel.ToRGBA(e.GradientEnd)
+	return start.Scaled(1 - pos).Add(end.Scaled(pos))
+}
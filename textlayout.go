@@ -0,0 +1,358 @@
+/*
+ * textlayout.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gopxl/pixel/text"
+)
+
+// Struct for text span, part of text line with
+// single style.
+type textSpan struct {
+	text   string
+	style  textStyle
+	x      float64       // position from the line start
+	pauses map[int]int64 // reveal pauses before runes with specified indices
+}
+
+// Struct for laid out line of text.
+type textLine struct {
+	spans  []textSpan
+	width  float64
+	height float64
+}
+
+// Struct for range of items in single laid out line.
+type textRange struct {
+	start, end int
+	hyphen     bool // line broken on soft hyphen
+}
+
+// Struct for text layout options.
+type layoutOptions struct {
+	width       float64 // width <= 0 means no limit
+	wrap        Wrap
+	hyphenation bool
+}
+
+// Struct for single rune of laid out text.
+type layoutItem struct {
+	r     rune
+	run   int // index of text run
+	atlas *text.Atlas
+	pause int64
+}
+
+const (
+	// Soft hyphen, invisible break opportunity in word.
+	softHyphen = '\u00AD'
+	// Ellipsis for truncated lines.
+	ellipsis = "..."
+)
+
+// layoutRuns breaks specified text runs into lines with
+// specified layout options.
+// Specified function is used to get atlas for each run
+// style.
+// Word wrap breaks lines after spaces and hyphens, before
+// and after ideographs, and on soft hyphens if hyphenation
+// is enabled. Words longer than maximal width are broken
+// between characters.
+func layoutRuns(runs []textRun, opts layoutOptions, atlas func(s textStyle) *text.Atlas) []textLine {
+	var (
+		items []layoutItem
+		pause int64
+	)
+	for i, run := range runs {
+		a := atlas(run.style)
+		pause += run.pause
+		for _, r := range strings.ReplaceAll(run.text, "\t", "    ") {
+			if r == softHyphen && !opts.hyphenation {
+				continue
+			}
+			item := layoutItem{r: r, run: i, atlas: a}
+			// Pause before the first visible rune.
+			if pause > 0 && r != softHyphen && !unicode.IsSpace(r) {
+				item.pause = pause
+				pause = 0
+			}
+			items = append(items, item)
+		}
+	}
+	var lines []textLine
+	for _, r := range breakLines(items, opts) {
+		line := newTextLine(runs, items[r.start:r.end], r.hyphen)
+		if r.end < len(items) && items[r.end].r == '\n' {
+			line.height = max(line.height, items[r.end].atlas.LineHeight())
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// breakLines breaks specified layout items into lines with
+// specified layout options.
+// Returns ranges of items in each line, new line items are
+// not included in any line.
+func breakLines(items []layoutItem, opts layoutOptions) []textRange {
+	var (
+		lines      []textRange
+		lineStart  int
+		lineWidth  float64
+		breakPoint = -1 // index of first item after break opportunity
+		softBreak  bool // break opportunity on soft hyphen
+	)
+	for i := 0; i < len(items); i++ {
+		item := items[i]
+		if item.r == '\n' {
+			lines = append(lines, textRange{start: lineStart, end: i})
+			lineStart = i + 1
+			lineWidth = 0
+			breakPoint = -1
+			continue
+		}
+		if opts.wrap == WrapWord && isIdeograph(item.r) {
+			breakPoint = i
+			softBreak = false
+		}
+		advance := itemAdvance(items, lineStart, i)
+		if opts.wrap != WrapNone && opts.width > 0 && lineWidth+advance > opts.width &&
+			i > lineStart && !unicode.IsSpace(item.r) {
+			lineEnd, hyphen := i, false
+			if opts.wrap == WrapWord && breakPoint > lineStart {
+				lineEnd, hyphen = breakPoint, softBreak
+			}
+			lines = append(lines, textRange{start: lineStart, end: lineEnd, hyphen: hyphen})
+			lineStart = lineEnd
+			for lineStart < i && items[lineStart].r == ' ' {
+				lineStart++
+			}
+			breakPoint = -1
+			lineWidth = 0
+			for j := lineStart; j < i; j++ {
+				lineWidth += itemAdvance(items, lineStart, j)
+			}
+			advance = itemAdvance(items, lineStart, i)
+		}
+		lineWidth += advance
+		if opts.wrap != WrapWord {
+			continue
+		}
+		switch {
+		case item.r == ' ', item.r == '-', isIdeograph(item.r):
+			breakPoint = i + 1
+			softBreak = false
+		case item.r == softHyphen:
+			breakPoint = i + 1
+			softBreak = true
+		}
+	}
+	return append(lines, textRange{start: lineStart, end: len(items)})
+}
+
+// newTextLine creates new text line from specified layout
+// items of specified text runs.
+// If hyphen is true then hyphen is added at the end of
+// the line.
+func newTextLine(runs []textRun, items []layoutItem, hyphen bool) textLine {
+	var (
+		line      textLine
+		span      textSpan
+		spanText  strings.Builder
+		spanRunes int
+		x, width  float64
+	)
+	addSpan := func() {
+		if spanText.Len() > 0 {
+			span.text = spanText.String()
+			line.spans = append(line.spans, span)
+			spanText.Reset()
+		}
+	}
+	for i, item := range items {
+		if i == 0 || items[i-1].run != item.run {
+			addSpan()
+			span = textSpan{style: runs[item.run].style, x: x}
+			spanRunes = 0
+		}
+		x += itemAdvance(items, 0, i)
+		line.height = max(line.height, item.atlas.LineHeight())
+		if item.r == softHyphen {
+			continue
+		}
+		if item.pause > 0 {
+			if span.pauses == nil {
+				span.pauses = make(map[int]int64)
+			}
+			span.pauses[spanRunes] += item.pause
+		}
+		spanText.WriteRune(item.r)
+		spanRunes++
+		if !unicode.IsSpace(item.r) {
+			width = x
+		}
+	}
+	if hyphen && len(items) > 0 {
+		last := items[len(items)-1]
+		spanText.WriteRune('-')
+		width = x + runeAdvance(last.atlas, -1, '-')
+	}
+	addSpan()
+	line.width = width
+	return line
+}
+
+// itemAdvance returns advance of layout item with
+// specified index, in line starting with item with
+// specified index.
+// Kerning is applied only between items of the same
+// text run.
+func itemAdvance(items []layoutItem, lineStart, i int) float64 {
+	item := items[i]
+	if item.r == softHyphen {
+		return 0
+	}
+	prev := rune(-1)
+	if i > lineStart && items[i-1].run == item.run && items[i-1].r != softHyphen {
+		prev = items[i-1].r
+	}
+	return runeAdvance(item.atlas, prev, item.r)
+}
+
+// ellipsizeLine truncates specified line to specified width
+// and adds ellipsis at the end of the line.
+// Specified function is used to get atlas for each span
+// style.
+func ellipsizeLine(line textLine, width float64, atlas func(s textStyle) *text.Atlas) textLine {
+	fitted := textLine{height: line.height}
+	for _, s := range line.spans {
+		a := atlas(s.style)
+		ellipsisWidth := 0.0
+		for _, r := range ellipsis {
+			ellipsisWidth += runeAdvance(a, -1, r)
+		}
+		var (
+			spanText  strings.Builder
+			x         = s.x
+			textWidth = s.x // width without trailing spaces
+			prev      = rune(-1)
+		)
+		for _, r := range s.text {
+			advance := runeAdvance(a, prev, r)
+			if x+advance+ellipsisWidth > width {
+				s.text = strings.TrimRightFunc(spanText.String(), unicode.IsSpace) + ellipsis
+				fitted.spans = append(fitted.spans, s)
+				fitted.width = textWidth + ellipsisWidth
+				return fitted
+			}
+			spanText.WriteRune(r)
+			x += advance
+			if !unicode.IsSpace(r) {
+				textWidth = x
+			}
+			prev = r
+		}
+		fitted.spans = append(fitted.spans, s)
+	}
+	fitted.width = line.width
+	return fitted
+}
+
+// linesWidth returns width of the widest of specified
+// lines.
+func linesWidth(lines []textLine) (width float64) {
+	for _, l := range lines {
+		width = max(width, l.width)
+	}
+	return
+}
+
+// linesReveal adds reveal pauses of specified lines to
+// specified map, with rune indices starting from specified
+// offset.
+// Returns number of runes in lines.
+func linesReveal(lines []textLine, offset int, pauses map[int]int64) int {
+	count := 0
+	for _, l := range lines {
+		for _, s := range l.spans {
+			for i, p := range s.pauses {
+				pauses[offset+count+i] += p
+			}
+			count += utf8.RuneCountInString(s.text)
+		}
+	}
+	return count
+}
+
+// linesRunes returns number of runes in specified lines.
+func linesRunes(lines []textLine) int {
+	count := 0
+	for _, l := range lines {
+		for _, s := range l.spans {
+			count += utf8.RuneCountInString(s.text)
+		}
+	}
+	return count
+}
+
+// splitRunes splits specified text after specified
+// number of runes.
+func splitRunes(s string, n int) (string, string) {
+	for i := range s {
+		if n <= 0 {
+			return s[:i], s[i:]
+		}
+		n--
+	}
+	return s, ""
+}
+
+// isIdeograph checks whether specified rune is an ideograph
+// or syllable of language written without spaces between
+// words.
+func isIdeograph(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// runeAdvance returns distance between dot positions
+// before and after drawing specified rune with specified
+// atlas, after specified previous rune.
+// Previous rune < 0 means no previous rune.
+func runeAdvance(a *text.Atlas, prev, r rune) float64 {
+	if !a.Contains(r) {
+		r = unicode.ReplacementChar
+	}
+	advance := a.Glyph(r).Advance
+	if prev >= 0 {
+		if !a.Contains(prev) {
+			prev = unicode.ReplacementChar
+		}
+		advance += a.Kern(prev, r)
+	}
+	return advance
+}
@@ -0,0 +1,115 @@
+/*
+ * textlayout_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gopxl/pixel/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// Atlas with fixed rune width of 7 pixels, used in
+// layout tests.
+var testAtlas = text.NewAtlas(basicfont.Face7x13, text.ASCII)
+
+// testLayout lays out specified text with specified
+// options and returns texts of all lines.
+func testLayout(s string, opts layoutOptions) []string {
+	runs := []textRun{{text: s}}
+	lines := layoutRuns(runs, opts, func(s textStyle) *text.Atlas {
+		return testAtlas
+	})
+	var texts []string
+	for _, l := range lines {
+		var b strings.Builder
+		for _, s := range l.spans {
+			b.WriteString(s.text)
+		}
+		texts = append(texts, b.String())
+	}
+	return texts
+}
+
+// TestLayoutRunsWrap tests breaking text into lines with
+// different wrap modes.
+func TestLayoutRunsWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		opts  layoutOptions
+		lines []string
+	}{
+		{"lorem ipsum dolor sit", layoutOptions{width: 70}, []string{"lorem ", "ipsum ", "dolor sit"}},
+		{"abcdefghijkl mn", layoutOptions{width: 70}, []string{"abcdefghij", "kl mn"}},
+		{"long-term x", layoutOptions{width: 56}, []string{"long-", "term x"}},
+		{"ab cdefghijkl", layoutOptions{width: 70, wrap: WrapChar}, []string{"ab cdefghi", "jkl"}},
+		{"lorem ipsum dolor", layoutOptions{width: 70, wrap: WrapNone}, []string{"lorem ipsum dolor"}},
+		{"ab\ncd", layoutOptions{}, []string{"ab", "cd"}},
+		{"abcdef\u00ADghijk", layoutOptions{width: 70, hyphenation: true}, []string{"abcdef-", "ghijk"}},
+		{"abcdef\u00ADghijk", layoutOptions{width: 70}, []string{"abcdefghij", "k"}},
+	}
+	for _, test := range tests {
+		lines := testLayout(test.text, test.opts)
+		if strings.Join(lines, "|") != strings.Join(test.lines, "|") {
+			t.Errorf("lines of '%s': %q, expected: %q", test.text, lines, test.lines)
+		}
+	}
+}
+
+// TestLayoutRunsWidth tests width of laid out lines.
+func TestLayoutRunsWidth(t *testing.T) {
+	runs := []textRun{{text: "lorem ipsum"}}
+	lines := layoutRuns(runs, layoutOptions{width: 70}, func(s textStyle) *text.Atlas {
+		return testAtlas
+	})
+	if len(lines) != 2 {
+		t.Fatalf("lines: %d, expected: 2", len(lines))
+	}
+	// Trailing space is not included in line width.
+	if lines[0].width != 35 {
+		t.Errorf("first line width: %f, expected: 35", lines[0].width)
+	}
+	if width := linesWidth(lines); width != 35 {
+		t.Errorf("lines width: %f, expected: 35", width)
+	}
+}
+
+// TestBreakLines tests ranges of laid out lines.
+func TestBreakLines(t *testing.T) {
+	var items []layoutItem
+	for _, r := range "ab cd\nef" {
+		items = append(items, layoutItem{r: r, atlas: testAtlas})
+	}
+	lines := breakLines(items, layoutOptions{width: 21})
+	expected := []textRange{{start: 0, end: 3}, {start: 3, end: 5}, {start: 6, end: 8}}
+	if len(lines) != len(expected) {
+		t.Fatalf("lines: %v, expected: %v", lines, expected)
+	}
+	for i, l := range lines {
+		if l != expected[i] {
+			t.Errorf("line %d: %v, expected: %v", i, l, expected[i])
+		}
+	}
+}
@@ -0,0 +1,454 @@
+/*
+ * theme.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+
+	"github.com/golang/freetype/truetype"
+
+	"github.com/gopxl/pixel"
+)
+
+// Struct for UI theme, with default styles for all
+// kinds of UI elements.
+// Theme styles are used by all UI elements constructors
+// for parameters not specified in the constructor
+// parameters.
+// Info window style main color is used by standalone info
+// windows, and secondary color by info windows of other UI
+// elements, like buttons or slots.
+// Size is used only by scroll button style, for scroll
+// buttons of all UI elements. Font size is used by scroll
+// button style, by slot list style for slot list scroll
+// buttons, by check slot style for slot labels and by info
+// window style.
+type Theme struct {
+	Font          *truetype.Font
+	Fonts         map[string]*truetype.Font
+	Text          Style
+	Button        Style
+	Switch        Style
+	List          Style
+	CheckSlot     Style
+	Slot          Style
+	SlotList      Style
+	Textbox       Style
+	Textedit      Style
+	TextArea      Style
+	ProgressBar   Style
+	MessageWindow Style
+	InfoWindow    Style
+	ScrollButton  Style
+	Scrollbar     Style
+	Panel         Style
+}
+
+// Struct for style of single kind of UI elements.
+// Size and font size are used for sub-elements,
+// like info windows, labels or scroll buttons, see
+// Theme for styles that use them.
+type Style struct {
+	MainColor     color.Color
+	SecColor      color.Color
+	AccentColor   color.Color
+	HoverColor    color.Color
+	PressedColor  color.Color
+	DisabledColor color.Color
+	FocusedColor  color.Color
+	ErrorColor    color.Color
+	Size          Size
+	FontSize      Size
+	Font          string
+	Background    *pixel.Sprite
+}
+
+// Interface for UI elements that can apply UI theme.
+type Themer interface {
+	ApplyTheme(t *Theme)
+}
+
+// Struct for theme file data.
+type themeData struct {
+	XMLName       xml.Name    `xml:"theme" json:"-"`
+	Font          string      `xml:"font,attr" json:"font"`
+	Fonts         []*fontData `xml:"fonts>font" json:"fonts"`
+	Text          *styleData  `xml:"text" json:"text"`
+	Button        *styleData  `xml:"button" json:"button"`
+	Switch        *styleData  `xml:"switch" json:"switch"`
+	List          *styleData  `xml:"list" json:"list"`
+	CheckSlot     *styleData  `xml:"check-slot" json:"check-slot"`
+	Slot          *styleData  `xml:"slot" json:"slot"`
+	SlotList      *styleData  `xml:"slot-list" json:"slot-list"`
+	Textbox       *styleData  `xml:"textbox" json:"textbox"`
+	Textedit      *styleData  `xml:"textedit" json:"textedit"`
+	TextArea      *styleData  `xml:"text-area" json:"text-area"`
+	ProgressBar   *styleData  `xml:"progress-bar" json:"progress-bar"`
+	MessageWindow *styleData  `xml:"message-window" json:"message-window"`
+	InfoWindow    *styleData  `xml:"info-window" json:"info-window"`
+	ScrollButton  *styleData  `xml:"scroll-button" json:"scroll-button"`
+	Scrollbar     *styleData  `xml:"scrollbar" json:"scrollbar"`
+	Panel         *styleData  `xml:"panel" json:"panel"`
+}
+
+// Struct for style data in theme file.
+type styleData struct {
+	MainColor     string `xml:"main-color,attr" json:"main-color"`
+	SecColor      string `xml:"sec-color,attr" json:"sec-color"`
+	AccentColor   string `xml:"accent-color,attr" json:"accent-color"`
+	HoverColor    string `xml:"hover-color,attr" json:"hover-color"`
+	PressedColor  string `xml:"pressed-color,attr" json:"pressed-color"`
+	DisabledColor string `xml:"disabled-color,attr" json:"disabled-color"`
+	FocusedColor  string `xml:"focused-color,attr" json:"focused-color"`
+	ErrorColor    string `xml:"error-color,attr" json:"error-color"`
+	Size          string `xml:"size,attr" json:"size"`
+	FontSize      string `xml:"font-size,attr" json:"font-size"`
+	Font          string `xml:"font,attr" json:"font"`
+	Background    string `xml:"background,attr" json:"background"`
+}
+
+// Struct for font data in theme file.
+type fontData struct {
+	Name string `xml:"name,attr" json:"name"`
+	Path string `xml:"path,attr" json:"path"`
+}
+
+// DefaultTheme returns default UI theme.
+func DefaultTheme() *Theme {
+	t := new(Theme)
+	t.Fonts = make(map[string]*truetype.Font)
+	t.Text = Style{
+		MainColor: colornames.White,
+	}
+	t.Button = Style{
+		HoverColor:    colornames.Crimson,
+		PressedColor:  colornames.Grey,
+		DisabledColor: colornames.Grey,
+	}
+	t.Switch = Style{
+		SecColor: colornames.Red,
+	}
+	t.CheckSlot = Style{
+		FontSize: SizeMedium,
+	}
+	t.SlotList = Style{
+		FontSize: SizeMini,
+	}
+	t.Slot = Style{
+		MainColor: pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+	}
+	t.Textbox = Style{
+		MainColor: pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		SecColor:  pixel.RGBA{0.2, 0.4, 0.8, 0.5},
+	}
+	t.Textedit = Style{
+		AccentColor:  pixel.RGBA{0.2, 0.4, 0.8, 0.5},
+		FocusedColor: colornames.Crimson,
+		ErrorColor:   colornames.Red,
+	}
+	t.TextArea = Style{
+		MainColor:   pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		AccentColor: pixel.RGBA{0.2, 0.4, 0.8, 0.5},
+	}
+	t.MessageWindow = Style{
+		DisabledColor: colornames.Darkgrey,
+	}
+	t.InfoWindow = Style{
+		MainColor: colornames.Black,
+		SecColor:  pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		FontSize:  SizeSmall,
+	}
+	t.ScrollButton = Style{
+		MainColor: colornames.Red,
+		Size:      SizeMini,
+		FontSize:  SizeMedium,
+	}
+	t.Scrollbar = Style{
+		MainColor:   pixel.RGBA{0.1, 0.1, 0.1, 0.5},
+		AccentColor: colornames.Red,
+		HoverColor:  colornames.Crimson,
+	}
+	return t
+}
+
+// LoadTheme loads UI theme from JSON or XML file with
+// specified path.
+// Styles not specified in the file are taken from the
+// default theme.
+// Font and background paths in the file are relative
+// to the theme file directory.
+func LoadTheme(path string) (*Theme, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file: %v", err)
+	}
+	data := new(themeData)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(file, data)
+	case ".xml":
+		err = xml.Unmarshal(file, data)
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal theme data: %v", err)
+	}
+	theme, err := buildTheme(data, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build theme: %v", err)
+	}
+	return theme, nil
+}
+
+// SetTheme sets specified theme as current UI theme.
+// Current theme is used by constructors of all UI elements
+// created after this call, to apply theme to existing elements
+// use ApplyTheme function of UI element.
+// Theme font(if specified) is set as main font of the
+// interface, all other theme fonts are registered under
+// their names.
+// Nil theme sets the default theme.
+func SetTheme(t *Theme) {
+	if t == nil {
+		t = DefaultTheme()
+	}
+	theme = t
+	if t.Font != nil {
+		SetMainFont(t.Font)
+	}
+	for name, f := range t.Fonts {
+		RegisterFont(name, f)
+	}
+}
+
+// CurrentTheme returns current UI theme.
+func CurrentTheme() *Theme {
+	return theme
+}
+
+// ParseColor parses specified text to color.
+// Supported formats are hex RGB or RGBA(e.g. '#ff0000' or
+// '#ff000080') and SVG color names(e.g. 'red').
+func ParseColor(text string) (color.Color, error) {
+	if !strings.HasPrefix(text, "#") {
+		c, ok := colornames.Map[strings.ToLower(text)]
+		if !ok {
+			return nil, fmt.Errorf("unknown color name: %s", text)
+		}
+		return c, nil
+	}
+	hex := text[1:]
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, fmt.Errorf("invalid color format: %s", text)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color value: %s", text)
+	}
+	c := color.NRGBA{
+		R: uint8(value >> 24),
+		G: uint8(value >> 16),
+		B: uint8(value >> 8),
+		A: uint8(value),
+	}
+	return c, nil
+}
+
+// ParseSize parses specified size name(e.g. 'small' or
+// 'big') to size.
+func ParseSize(text string) (Size, error) {
+	switch strings.ToLower(text) {
+	case "mini":
+		return SizeMini, nil
+	case "small":
+		return SizeSmall, nil
+	case "medium":
+		return SizeMedium, nil
+	case "big":
+		return SizeBig, nil
+	case "huge":
+		return SizeHuge, nil
+	default:
+		return SizeMini, fmt.Errorf("unknown size name: %s", text)
+	}
+}
+
+// buildTheme creates new theme from specified theme data.
+// Paths in data are resolved relative to specified directory.
+func buildTheme(data *themeData, dir string) (*Theme, error) {
+	t := DefaultTheme()
+	if len(data.Font) > 0 {
+		font, err := LoadFont(filepath.Join(dir, data.Font))
+		if err != nil {
+			return nil, err
+		}
+		t.Font = font
+	}
+	for _, fd := range data.Fonts {
+		font, err := LoadFont(filepath.Join(dir, fd.Path))
+		if err != nil {
+			return nil, fmt.Errorf("font: %s: %v", fd.Name, err)
+		}
+		t.Fonts[fd.Name] = font
+	}
+	styles := []struct {
+		data  *styleData
+		style *Style
+	}{
+		{data.Text, &t.Text},
+		{data.Button, &t.Button},
+		{data.Switch, &t.Switch},
+		{data.List, &t.List},
+		{data.CheckSlot, &t.CheckSlot},
+		{data.Slot, &t.Slot},
+		{data.SlotList, &t.SlotList},
+		{data.Textbox, &t.Textbox},
+		{data.Textedit, &t.Textedit},
+		{data.TextArea, &t.TextArea},
+		{data.ProgressBar, &t.ProgressBar},
+		{data.MessageWindow, &t.MessageWindow},
+		{data.InfoWindow, &t.InfoWindow},
+		{data.ScrollButton, &t.ScrollButton},
+		{data.Scrollbar, &t.Scrollbar},
+		{data.Panel, &t.Panel},
+	}
+	for _, s := range styles {
+		if s.data == nil {
+			continue
+		}
+		err := applyStyleData(s.style, s.data, dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// applyStyleData sets all values specified in style data
+// to specified style.
+func applyStyleData(s *Style, data *styleData, dir string) error {
+	colors := []struct {
+		value string
+		color *color.Color
+	}{
+		{data.MainColor, &s.MainColor},
+		{data.SecColor, &s.SecColor},
+		{data.AccentColor, &s.AccentColor},
+		{data.HoverColor, &s.HoverColor},
+		{data.PressedColor, &s.PressedColor},
+		{data.DisabledColor, &s.DisabledColor},
+		{data.FocusedColor, &s.FocusedColor},
+		{data.ErrorColor, &s.ErrorColor},
+	}
+	for _, c := range colors {
+		if len(c.value) < 1 {
+			continue
+		}
+		col, err := ParseColor(c.value)
+		if err != nil {
+			return err
+		}
+		*c.color = col
+	}
+	if len(data.Size) > 0 {
+		size, err := ParseSize(data.Size)
+		if err != nil {
+			return err
+		}
+		s.Size = size
+	}
+	if len(data.FontSize) > 0 {
+		size, err := ParseSize(data.FontSize)
+		if err != nil {
+			return err
+		}
+		s.FontSize = size
+	}
+	if len(data.Font) > 0 {
+		s.Font = data.Font
+	}
+	if len(data.Background) > 0 {
+		spr, err := loadSprite(filepath.Join(dir, data.Background))
+		if err != nil {
+			return fmt.Errorf("unable to load background: %v", err)
+		}
+		s.Background = spr
+	}
+	return nil
+}
+
+// applyChildrenTheme applies specified theme to all
+// specified elements that support themes.
+func applyChildrenTheme(children []Widget, t *Theme) {
+	for _, c := range children {
+		if tc, ok := c.(Themer); ok {
+			tc.ApplyTheme(t)
+		}
+	}
+}
+
+// applyScrollButtonTheme applies scroll button style of specified
+// theme to specified button.
+// Specified color is used as button color instead of style
+// color, if not nil.
+func applyScrollButtonTheme(b *Button, t *Theme, c color.Color) {
+	b.ApplyTheme(t)
+	b.size = t.ScrollButton.Size.ButtonSize(b.shape)
+	b.bgSpr = t.ScrollButton.Background
+	if c == nil {
+		c = t.ScrollButton.MainColor
+	}
+	b.SetColor(c)
+}
+
+// loadSprite loads sprite from image file with
+// specified path.
+func loadSprite(path string) (*pixel.Sprite, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open image file: %v", err)
+	}
+	defer file.Close()
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode image: %v", err)
+	}
+	pic := pixel.PictureDataFromImage(img)
+	return pixel.NewSprite(pic, pic.Bounds()), nil
+}
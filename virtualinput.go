@@ -0,0 +1,240 @@
+/*
+ * virtualinput.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+)
+
+// Struct for scriptable input source, that can be used
+// to update UI elements without system window.
+// Input state set between two updates is visible as
+// state of the current frame, just like window input
+// polled in window update.
+type VirtualInput struct {
+	pressed     map[pixelgl.Button]bool
+	prevPressed map[pixelgl.Button]bool
+	repeated    map[pixelgl.Button]bool
+	mousePos    pixel.Vec
+	scroll      pixel.Vec
+	typed       string
+	delta       int64
+	joysticks   map[pixelgl.Joystick]bool
+	joyPressed  map[joystickButton]bool
+	joyPrev     map[joystickButton]bool
+	joyAxes     map[joystickAxis]float64
+	clipboard   string
+}
+
+// Struct for button of specific joystick.
+type joystickButton struct {
+	js     pixelgl.Joystick
+	button pixelgl.GamepadButton
+}
+
+// Struct for axis of specific joystick.
+type joystickAxis struct {
+	js   pixelgl.Joystick
+	axis pixelgl.GamepadAxis
+}
+
+// NewVirtualInput creates new virtual input source.
+func NewVirtualInput() *VirtualInput {
+	vi := new(VirtualInput)
+	vi.pressed = make(map[pixelgl.Button]bool)
+	vi.prevPressed = make(map[pixelgl.Button]bool)
+	vi.repeated = make(map[pixelgl.Button]bool)
+	vi.joysticks = make(map[pixelgl.Joystick]bool)
+	vi.joyPressed = make(map[joystickButton]bool)
+	vi.joyPrev = make(map[joystickButton]bool)
+	vi.joyAxes = make(map[joystickAxis]float64)
+	return vi
+}
+
+// Update moves input to the next frame, with specified
+// time from last update in milliseconds.
+// All 'just pressed/released' states, typed text and
+// mouse scroll from previous frame are cleared.
+func (vi *VirtualInput) Update(delta int64) {
+	vi.prevPressed = make(map[pixelgl.Button]bool)
+	for b, p := range vi.pressed {
+		vi.prevPressed[b] = p
+	}
+	vi.joyPrev = make(map[joystickButton]bool)
+	for b, p := range vi.joyPressed {
+		vi.joyPrev[b] = p
+	}
+	vi.repeated = make(map[pixelgl.Button]bool)
+	vi.scroll = pixel.ZV
+	vi.typed = ""
+	vi.delta = delta
+}
+
+// Press sets specified button as pressed.
+func (vi *VirtualInput) Press(button pixelgl.Button) {
+	vi.pressed[button] = true
+}
+
+// Release sets specified button as released.
+func (vi *VirtualInput) Release(button pixelgl.Button) {
+	vi.pressed[button] = false
+}
+
+// Repeat sets specified button as repeated in
+// the current frame.
+func (vi *VirtualInput) Repeat(button pixelgl.Button) {
+	vi.repeated[button] = true
+}
+
+// Type adds specified text to text typed in the
+// current frame.
+func (vi *VirtualInput) Type(text string) {
+	vi.typed += text
+}
+
+// Scroll adds specified vector to mouse scroll in
+// the current frame.
+func (vi *VirtualInput) Scroll(scroll pixel.Vec) {
+	vi.scroll = vi.scroll.Add(scroll)
+}
+
+// SetMousePosition sets specified position as current
+// mouse position.
+func (vi *VirtualInput) SetMousePosition(pos pixel.Vec) {
+	vi.mousePos = pos
+}
+
+// ConnectJoystick sets specified joystick as connected.
+func (vi *VirtualInput) ConnectJoystick(js pixelgl.Joystick) {
+	vi.joysticks[js] = true
+}
+
+// DisconnectJoystick sets specified joystick as disconnected.
+func (vi *VirtualInput) DisconnectJoystick(js pixelgl.Joystick) {
+	delete(vi.joysticks, js)
+}
+
+// PressJoystickButton sets specified button of specified
+// joystick as pressed.
+func (vi *VirtualInput) PressJoystickButton(js pixelgl.Joystick, button pixelgl.GamepadButton) {
+	vi.joyPressed[joystickButton{js, button}] = true
+}
+
+// ReleaseJoystickButton sets specified button of specified
+// joystick as released.
+func (vi *VirtualInput) ReleaseJoystickButton(js pixelgl.Joystick, button pixelgl.GamepadButton) {
+	vi.joyPressed[joystickButton{js, button}] = false
+}
+
+// SetJoystickAxis sets specified value as current value of
+// specified axis of specified joystick.
+func (vi *VirtualInput) SetJoystickAxis(js pixelgl.Joystick, axis pixelgl.GamepadAxis, value float64) {
+	vi.joyAxes[joystickAxis{js, axis}] = value
+}
+
+// Pressed checks whether specified button is pressed.
+func (vi *VirtualInput) Pressed(button pixelgl.Button) bool {
+	return vi.pressed[button]
+}
+
+// JustPressed checks whether specified button was pressed
+// in the current frame.
+func (vi *VirtualInput) JustPressed(button pixelgl.Button) bool {
+	return vi.pressed[button] && !vi.prevPressed[button]
+}
+
+// JustReleased checks whether specified button was released
+// in the current frame.
+func (vi *VirtualInput) JustReleased(button pixelgl.Button) bool {
+	return !vi.pressed[button] && vi.prevPressed[button]
+}
+
+// Repeated checks whether specified button was repeated
+// in the current frame.
+func (vi *VirtualInput) Repeated(button pixelgl.Button) bool {
+	return vi.repeated[button]
+}
+
+// MousePosition returns current mouse position.
+func (vi *VirtualInput) MousePosition() pixel.Vec {
+	return vi.mousePos
+}
+
+// MouseScroll returns mouse scroll in the current frame.
+func (vi *VirtualInput) MouseScroll() pixel.Vec {
+	return vi.scroll
+}
+
+// Typed returns text typed in the current frame.
+func (vi *VirtualInput) Typed() string {
+	return vi.typed
+}
+
+// Delta returns time from last update in milliseconds.
+func (vi *VirtualInput) Delta() int64 {
+	return vi.delta
+}
+
+// Clipboard returns content of the virtual clipboard.
+func (vi *VirtualInput) Clipboard() string {
+	return vi.clipboard
+}
+
+// SetClipboard sets specified text as content of
+// the virtual clipboard.
+func (vi *VirtualInput) SetClipboard(s string) {
+	vi.clipboard = s
+}
+
+// JoystickPresent checks whether specified joystick is connected.
+func (vi *VirtualInput) JoystickPresent(js pixelgl.Joystick) bool {
+	return vi.joysticks[js]
+}
+
+// JoystickPressed checks whether specified button of specified
+// joystick is pressed.
+func (vi *VirtualInput) JoystickPressed(js pixelgl.Joystick, button pixelgl.GamepadButton) bool {
+	return vi.joyPressed[joystickButton{js, button}]
+}
+
+// JoystickJustPressed checks whether specified button of specified
+// joystick was pressed in the current frame.
+func (vi *VirtualInput) JoystickJustPressed(js pixelgl.Joystick, button pixelgl.GamepadButton) bool {
+	b := joystickButton{js, button}
+	return vi.joyPressed[b] && !vi.joyPrev[b]
+}
+
+// JoystickJustReleased checks whether specified button of specified
+// joystick was released in the current frame.
+func (vi *VirtualInput) JoystickJustReleased(js pixelgl.Joystick, button pixelgl.GamepadButton) bool {
+	b := joystickButton{js, button}
+	return !vi.joyPressed[b] && vi.joyPrev[b]
+}
+
+// JoystickAxis returns current value of specified axis of
+// specified joystick.
+func (vi *VirtualInput) JoystickAxis(js pixelgl.Joystick, axis pixelgl.GamepadAxis) float64 {
+	return vi.joyAxes[joystickAxis{js, axis}]
+}
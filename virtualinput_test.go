@@ -0,0 +1,184 @@
+/*
+ * virtualinput_test.go
+ *
+ * Copyright 2024 Dariusz Sikora <ds@isangeles.dev>
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 2 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program; if not, write to the Free Software
+ * Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston,
+ * MA 02110-1301, USA.
+ *
+ *
+ */
+
+package mtk
+
+import (
+	"testing"
+
+	"github.com/gopxl/pixel"
+	"github.com/gopxl/pixel/pixelgl"
+	"golang.org/x/image/colornames"
+)
+
+// Target that discards everything drawn on it, used to
+// calculate draw areas of UI elements in tests.
+type testTarget struct{}
+
+// Triangles made by test target.
+type testTriangles struct {
+	*pixel.TrianglesData
+}
+
+// Picture made by test target.
+type testPicture struct {
+	pixel.Picture
+}
+
+func (testTarget) MakeTriangles(t pixel.Triangles) pixel.TargetTriangles {
+	return testTriangles{pixel.MakeTrianglesData(t.Len())}
+}
+
+func (testTarget) MakePicture(p pixel.Picture) pixel.TargetPicture {
+	return testPicture{p}
+}
+
+func (testTriangles) Draw() {}
+
+func (testPicture) Draw(t pixel.TargetTriangles) {}
+
+// TestVirtualInputButtonClick tests scripted mouse click
+// on button.
+func TestVirtualInputButtonClick(t *testing.T) {
+	SetResolution(pixel.V(1920, 1080))
+	b := NewButton(Params{
+		Size:      SizeMedium,
+		Shape:     ShapeRectangle,
+		MainColor: colornames.Red,
+	})
+	clicks := 0
+	b.SetOnClickFunc(func(b *Button) {
+		clicks++
+	})
+	b.Draw(testTarget{}, Matrix().Moved(pixel.V(100, 100)))
+	area := b.DrawArea()
+	if area.W() <= 0 || area.H() <= 0 {
+		t.Fatalf("button draw area: %v, expected non-empty area", area)
+	}
+	in := NewVirtualInput()
+	// Press and release just outside the button.
+	in.SetMousePosition(pixel.V(area.Max.X+1, area.Center().Y))
+	in.Press(pixelgl.MouseButtonLeft)
+	b.Update(in)
+	in.Update(16)
+	in.Release(pixelgl.MouseButtonLeft)
+	b.Update(in)
+	if clicks != 0 {
+		t.Fatalf("clicks after click outside: %d, expected: 0", clicks)
+	}
+	// Press and release inside the button.
+	in.Update(16)
+	in.SetMousePosition(area.Min.Add(pixel.V(2, 2)))
+	in.Press(pixelgl.MouseButtonLeft)
+	b.Update(in)
+	if clicks != 0 {
+		t.Fatalf("clicks after press: %d, expected: 0", clicks)
+	}
+	in.Update(16)
+	in.Release(pixelgl.MouseButtonLeft)
+	b.Update(in)
+	if clicks != 1 {
+		t.Fatalf("clicks after release: %d, expected: 1", clicks)
+	}
+}
+
+// TestVirtualInputTexteditTyping tests scripted typing
+// into text edit.
+func TestVirtualInputTexteditTyping(t *testing.T) {
+	SetResolution(pixel.V(1920, 1080))
+	te := NewTextedit(Params{
+		SizeRaw:   pixel.V(200, 30),
+		FontSize:  SizeMedium,
+		MainColor: colornames.Grey,
+	})
+	te.Draw(testTarget{}, Matrix().Moved(pixel.V(200, 100)))
+	area := te.DrawArea()
+	in := NewVirtualInput()
+	// Typing without focus.
+	in.Type("abc")
+	te.Update(in)
+	if te.Text() != "" {
+		t.Fatalf("text typed without focus: '%s', expected: ''", te.Text())
+	}
+	// Focus with click and type.
+	in.Update(16)
+	in.SetMousePosition(area.Center())
+	in.Press(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	if !te.Focused() {
+		t.Fatalf("text edit not focused after click")
+	}
+	in.Update(16)
+	in.Release(pixelgl.MouseButtonLeft)
+	in.Type("hello")
+	te.Update(in)
+	in.Update(16)
+	in.Type(" world")
+	te.Update(in)
+	if te.Text() != "hello world" {
+		t.Fatalf("typed text: '%s', expected: 'hello world'", te.Text())
+	}
+	if te.Caret() != 11 {
+		t.Fatalf("caret after typing: %d, expected: 11", te.Caret())
+	}
+	// Remove last character.
+	in.Update(16)
+	in.Press(pixelgl.KeyBackspace)
+	te.Update(in)
+	if te.Text() != "hello worl" {
+		t.Fatalf("text after backspace: '%s', expected: 'hello worl'", te.Text())
+	}
+	if te.Caret() != 10 {
+		t.Fatalf("caret after backspace: %d, expected: 10", te.Caret())
+	}
+	// Move caret with clicks at the field start and end.
+	in.Update(16)
+	in.Release(pixelgl.KeyBackspace)
+	in.SetMousePosition(pixel.V(area.Min.X+1, area.Center().Y))
+	in.Press(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	if te.Caret() != 0 {
+		t.Fatalf("caret after click at field start: %d, expected: 0", te.Caret())
+	}
+	in.Update(16)
+	in.Release(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	in.Update(16)
+	in.SetMousePosition(pixel.V(area.Max.X-1, area.Center().Y))
+	in.Press(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	if te.Caret() != 10 {
+		t.Fatalf("caret after click at field end: %d, expected: 10", te.Caret())
+	}
+	// Click just outside the field.
+	in.Update(16)
+	in.Release(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	in.Update(16)
+	in.SetMousePosition(pixel.V(area.Max.X+1, area.Center().Y))
+	in.Press(pixelgl.MouseButtonLeft)
+	te.Update(in)
+	if te.Focused() {
+		t.Fatalf("text edit focused after click outside")
+	}
+}